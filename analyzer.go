@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"maps"
 	slashpath "path"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -132,8 +133,62 @@ func (p *enumAnalysis) addSchemaEnum(key string, enum []any) {
 	p.addEnum(key, enum)
 }
 
+type extensionAnalysis struct {
+	schemas       map[string]spec.Extensions
+	parameters    map[string]spec.Extensions
+	headers       map[string]spec.Extensions
+	items         map[string]spec.Extensions
+	operations    map[string]spec.Extensions
+	pathItems     map[string]spec.Extensions
+	responses     map[string]spec.Extensions
+	allExtensions map[string]spec.Extensions
+}
+
+func (e *extensionAnalysis) addExtensions(key string, extensions spec.Extensions) {
+	e.allExtensions["#"+key] = extensions
+}
+
+func (e *extensionAnalysis) addSchemaExtensions(key string, extensions spec.Extensions) {
+	e.schemas["#"+key] = extensions
+	e.addExtensions(key, extensions)
+}
+
+func (e *extensionAnalysis) addParameterExtensions(key string, extensions spec.Extensions) {
+	e.parameters["#"+key] = extensions
+	e.addExtensions(key, extensions)
+}
+
+func (e *extensionAnalysis) addHeaderExtensions(key string, extensions spec.Extensions) {
+	e.headers["#"+key] = extensions
+	e.addExtensions(key, extensions)
+}
+
+func (e *extensionAnalysis) addItemsExtensions(key string, extensions spec.Extensions) {
+	e.items["#"+key] = extensions
+	e.addExtensions(key, extensions)
+}
+
+func (e *extensionAnalysis) addOperationExtensions(key string, extensions spec.Extensions) {
+	e.operations["#"+key] = extensions
+	e.addExtensions(key, extensions)
+}
+
+func (e *extensionAnalysis) addPathItemExtensions(key string, extensions spec.Extensions) {
+	e.pathItems["#"+key] = extensions
+	e.addExtensions(key, extensions)
+}
+
+func (e *extensionAnalysis) addResponseExtensions(key string, extensions spec.Extensions) {
+	e.responses["#"+key] = extensions
+	e.addExtensions(key, extensions)
+}
+
 // Spec is an analyzed specification object. It takes a swagger spec object and turns it into a registry
 // with a bunch of utility methods to act on the information in the spec.
+//
+// Query methods are safe for concurrent use, as long as neither the analyzed spec (e.g. with [Spec.Reanalyze]
+// or [Flatten]) nor the underlying document are updated at the same time. Use a [Snapshot] to share
+// an analyzed spec with readers while it is being updated.
 type Spec struct {
 	spec        *spec.Swagger
 	consumes    map[string]struct{}
@@ -143,9 +198,15 @@ type Spec struct {
 	references  referenceAnalysis
 	patterns    patternAnalysis
 	enums       enumAnalysis
+	extensions  extensionAnalysis
+	usages      usageAnalysis
 	allSchemas  map[string]SchemaRef
 	allOfs      map[string]SchemaRef
 	mangler     mangling.NameMangler
+	readOnly    bool
+
+	paramDiagnostics bool
+	positions        *PositionMap
 }
 
 // New takes a swagger spec object and returns an analyzed spec document.
@@ -163,7 +224,11 @@ func New(doc *spec.Swagger, opts ...Option) *Spec {
 		references: referenceAnalysis{},
 		patterns:   patternAnalysis{},
 		enums:      enumAnalysis{},
+		extensions: extensionAnalysis{},
 		mangler:    mangling.NewNameMangler(o.manglerOpts...),
+
+		paramDiagnostics: o.paramDiagnostics,
+		positions:        o.positions,
 	}
 
 	a.reset()
@@ -328,8 +393,7 @@ func (s *Spec) ParametersFor(operationID string) []spec.Parameter {
 func (s *Spec) SafeParametersFor(operationID string, callmeOnError ErrorOnParamFunc) []spec.Parameter {
 	gatherParams := func(pi *spec.PathItem, op *spec.Operation) []spec.Parameter {
 		bag := make(map[string]spec.Parameter)
-		s.paramsAsMap(pi.Parameters, bag, callmeOnError)
-		s.paramsAsMap(op.Parameters, bag, callmeOnError)
+		s.gatherParams(pi.Parameters, op.Parameters, bag, callmeOnError)
 
 		res := make([]spec.Parameter, 0, len(bag))
 		for _, v := range bag {
@@ -387,8 +451,7 @@ func (s *Spec) ParamsFor(method, path string) map[string]spec.Parameter {
 func (s *Spec) SafeParamsFor(method, path string, callmeOnError ErrorOnParamFunc) map[string]spec.Parameter {
 	res := make(map[string]spec.Parameter)
 	if pi, ok := s.spec.Paths.Paths[path]; ok {
-		s.paramsAsMap(pi.Parameters, res, callmeOnError)
-		s.paramsAsMap(s.operations[strings.ToUpper(method)][path].Parameters, res, callmeOnError)
+		s.gatherParams(pi.Parameters, s.operations[strings.ToUpper(method)][path].Parameters, res, callmeOnError)
 	}
 
 	return res
@@ -645,6 +708,80 @@ func (s *Spec) AllEnums() map[string][]any {
 	return cloneEnumMap(s.enums.allEnums)
 }
 
+// SchemaExtensions returns all the vendor extensions found in schemas
+// the map is cloned to avoid accidental changes.
+func (s *Spec) SchemaExtensions() map[string]spec.Extensions {
+	return cloneExtensionsMap(s.extensions.schemas)
+}
+
+// ParameterExtensions returns all the vendor extensions found in parameters
+// the map is cloned to avoid accidental changes.
+func (s *Spec) ParameterExtensions() map[string]spec.Extensions {
+	return cloneExtensionsMap(s.extensions.parameters)
+}
+
+// HeaderExtensions returns all the vendor extensions found in response headers
+// the map is cloned to avoid accidental changes.
+func (s *Spec) HeaderExtensions() map[string]spec.Extensions {
+	return cloneExtensionsMap(s.extensions.headers)
+}
+
+// ItemsExtensions returns all the vendor extensions found in simple array items
+// the map is cloned to avoid accidental changes.
+func (s *Spec) ItemsExtensions() map[string]spec.Extensions {
+	return cloneExtensionsMap(s.extensions.items)
+}
+
+// OperationExtensions returns all the vendor extensions found in operations
+// the map is cloned to avoid accidental changes.
+func (s *Spec) OperationExtensions() map[string]spec.Extensions {
+	return cloneExtensionsMap(s.extensions.operations)
+}
+
+// PathItemExtensions returns all the vendor extensions found in path items
+// the map is cloned to avoid accidental changes.
+func (s *Spec) PathItemExtensions() map[string]spec.Extensions {
+	return cloneExtensionsMap(s.extensions.pathItems)
+}
+
+// ResponseExtensions returns all the vendor extensions found in responses
+// the map is cloned to avoid accidental changes.
+func (s *Spec) ResponseExtensions() map[string]spec.Extensions {
+	return cloneExtensionsMap(s.extensions.responses)
+}
+
+// AllExtensions returns all the vendor extensions found in the spec, indexed by JSON pointer
+// the map is cloned to avoid accidental changes.
+func (s *Spec) AllExtensions() map[string]spec.Extensions {
+	return cloneExtensionsMap(s.extensions.allExtensions)
+}
+
+// ExtensionsNamed returns the values of some vendor extension (e.g. "x-nullable"), indexed by
+// the JSON pointer of the construct which declares it.
+//
+// Extension names are matched case-insensitively.
+func (s *Spec) ExtensionsNamed(name string) map[string]any {
+	res := make(map[string]any, allocSmallMap)
+	for key, extensions := range s.extensions.allExtensions {
+		if value, ok := extensions[name]; ok {
+			res[key] = value
+
+			continue
+		}
+
+		// extensions are stored with the case of their declaration
+		for _, extension := range sortedKeys(extensions) {
+			if strings.EqualFold(extension, name) {
+				res[key] = extensions[extension]
+
+				break
+			}
+		}
+	}
+
+	return res
+}
+
 func (s *Spec) mapKeyFromParam(param *spec.Parameter) string {
 	return fmt.Sprintf("%s#%s", param.In, s.fieldNameFromParam(param))
 }
@@ -671,29 +808,64 @@ func (s *Spec) structMapKeys(mp map[string]struct{}) []string {
 	return result
 }
 
-func (s *Spec) paramsAsMap(parameters []spec.Parameter, res map[string]spec.Parameter, callmeOnError ErrorOnParamFunc) {
+// gatherParams merges path-level and operation-level parameters.
+func (s *Spec) gatherParams(pathParams, opParams []spec.Parameter, res map[string]spec.Parameter, callmeOnError ErrorOnParamFunc) {
+	pathOK := s.paramsAsMap(pathParams, res, callmeOnError)
+	opOK := s.paramsAsMap(opParams, res, callmeOnError)
+
+	if diagnose := s.paramDiagnosticsSink(callmeOnError); diagnose != nil && pathOK && opOK {
+		s.diagnoseBodyParams(res, diagnose)
+	}
+}
+
+// paramDiagnosticsSink yields the callback receiving parameter diagnostics, or nil when diagnostics are disabled.
+//
+// Diagnostics are advisory: unlike resolution errors, they are ignored when no callback is provided.
+func (s *Spec) paramDiagnosticsSink(callmeOnError ErrorOnParamFunc) ErrorOnParamFunc {
+	switch {
+	case !s.paramDiagnostics:
+		return nil
+	case callmeOnError == nil:
+		return func(spec.Parameter, error) bool { return true }
+	default:
+		return callmeOnError
+	}
+}
+
+// paramsAsMap resolves parameters and adds them to res.
+//
+// It returns false whenever the callback required to bail out of this list of parameters.
+func (s *Spec) paramsAsMap(parameters []spec.Parameter, res map[string]spec.Parameter, callmeOnError ErrorOnParamFunc) bool {
+	diagnose := s.paramDiagnosticsSink(callmeOnError)
+	if callmeOnError == nil {
+		callmeOnError = func(_ spec.Parameter, err error) bool {
+			panic(err)
+		}
+	}
+
+	var level map[string]struct{}
+	if diagnose != nil {
+		level = make(map[string]struct{}, len(parameters))
+	}
+
 	for _, param := range parameters {
 		pr := param
 		if pr.Ref.String() == "" {
-			res[s.mapKeyFromParam(&pr)] = pr
+			if !s.addParam(pr, res, level, diagnose) {
+				return false
+			}
 
 			continue
 		}
 
 		// resolve $ref
-		if callmeOnError == nil {
-			callmeOnError = func(_ spec.Parameter, err error) bool {
-				panic(err)
-			}
-		}
-
 		obj, _, err := pr.Ref.GetPointer().Get(s.spec)
 		if err != nil {
 			if callmeOnError(param, ErrInvalidRef(pr.Ref.String())) {
 				continue
 			}
 
-			break
+			return false
 		}
 
 		objAsParam, ok := obj.(spec.Parameter)
@@ -702,12 +874,93 @@ func (s *Spec) paramsAsMap(parameters []spec.Parameter, res map[string]spec.Para
 				continue
 			}
 
-			break
+			return false
 		}
 
 		pr = objAsParam
-		res[s.mapKeyFromParam(&pr)] = pr
+		if !s.addParam(pr, res, level, diagnose) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// addParam adds a resolved parameter to res.
+//
+// With diagnostics enabled, level holds the keys of the parameters already added at the same level,
+// and diagnostics are reported to diagnose.
+func (s *Spec) addParam(param spec.Parameter, res map[string]spec.Parameter, level map[string]struct{}, diagnose ErrorOnParamFunc) bool {
+	key := s.mapKeyFromParam(&param)
+	if level == nil {
+		res[key] = param
+
+		return true
+	}
+
+	previous, overrides := res[key]
+	_, duplicate := level[key]
+	level[key] = struct{}{}
+	res[key] = param
+
+	switch {
+	case duplicate:
+		return diagnose(param, ErrDuplicateParam(key))
+	case overrides && !compatibleParams(previous, param):
+		return diagnose(param, ErrIncompatibleParamOverride(key, paramType(previous), paramType(param)))
+	default:
+		return true
+	}
+}
+
+// diagnoseBodyParams reports multiple body parameters, or body parameters combined with formData parameters.
+func (s *Spec) diagnoseBodyParams(res map[string]spec.Parameter, diagnose ErrorOnParamFunc) {
+	var bodies, formData []spec.Parameter
+	for _, key := range sortedKeys(res) {
+		switch param := res[key]; param.In {
+		case "body":
+			bodies = append(bodies, param)
+		case "formData":
+			formData = append(formData, param)
+		}
+	}
+
+	if len(bodies) > 1 {
+		names := make([]string, 0, len(bodies))
+		for _, body := range bodies {
+			names = append(names, body.Name)
+		}
+
+		if !diagnose(bodies[1], ErrMultipleBodyParams(names)) {
+			return
+		}
+	}
+
+	if len(bodies) > 0 && len(formData) > 0 {
+		_ = diagnose(formData[0], ErrBodyWithFormData(bodies[0].Name, formData[0].Name))
+	}
+}
+
+// compatibleParams is true when an operation-level parameter may safely override a path-level parameter.
+func compatibleParams(pathParam, opParam spec.Parameter) bool {
+	if pathParam.Type != opParam.Type {
+		return false
+	}
+
+	if pathParam.Items != nil && opParam.Items != nil {
+		return pathParam.Items.Type == opParam.Items.Type
 	}
+
+	return true
+}
+
+// paramType describes the type of a parameter, with the type of its items for arrays, e.g. "array[string]".
+func paramType(param spec.Parameter) string {
+	if param.Type == "array" && param.Items != nil {
+		return fmt.Sprintf("%s[%s]", param.Type, param.Items.Type)
+	}
+
+	return param.Type
 }
 
 func (s *Spec) reset() {
@@ -735,6 +988,16 @@ func (s *Spec) reset() {
 	s.enums.items = make(map[string][]any, allocLargeMap)
 	s.enums.schemas = make(map[string][]any, allocLargeMap)
 	s.enums.allEnums = make(map[string][]any, allocLargeMap)
+	s.extensions.schemas = make(map[string]spec.Extensions, allocLargeMap)
+	s.extensions.parameters = make(map[string]spec.Extensions, allocLargeMap)
+	s.extensions.headers = make(map[string]spec.Extensions, allocLargeMap)
+	s.extensions.items = make(map[string]spec.Extensions, allocLargeMap)
+	s.extensions.operations = make(map[string]spec.Extensions, allocLargeMap)
+	s.extensions.pathItems = make(map[string]spec.Extensions, allocLargeMap)
+	s.extensions.responses = make(map[string]spec.Extensions, allocLargeMap)
+	s.extensions.allExtensions = make(map[string]spec.Extensions, allocLargeMap)
+	s.usages.roots = make(map[string]SchemaUsage, allocLargeMap)
+	s.usages.schemas = nil
 }
 
 func (s *Spec) reload() {
@@ -743,73 +1006,115 @@ func (s *Spec) reload() {
 }
 
 func (s *Spec) initialize() {
-	for _, c := range s.spec.Consumes {
-		s.consumes[c] = struct{}{}
-	}
-	for _, c := range s.spec.Produces {
-		s.produces[c] = struct{}{}
-	}
-	for _, ss := range s.spec.Security {
-		for k := range ss {
-			s.authSchemes[k] = struct{}{}
-		}
-	}
 	for path, pathItem := range s.AllPaths() {
 		s.analyzeOperations(path, &pathItem) //#nosec
 	}
 
 	for name, parameter := range s.spec.Parameters {
-		refPref := slashpath.Join("/parameters", jsonpointer.Escape(name))
-		if parameter.Items != nil {
-			s.analyzeItems("items", parameter.Items, refPref, "parameter")
-		}
-		if parameter.In == "body" && parameter.Schema != nil {
-			s.analyzeSchema("schema", parameter.Schema, refPref)
-		}
-		if parameter.Pattern != "" {
-			s.patterns.addParameterPattern(refPref, parameter.Pattern)
-		}
-		if len(parameter.Enum) > 0 {
-			s.enums.addParameterEnum(refPref, parameter.Enum)
-		}
+		s.analyzeSharedParameter(name, parameter)
 	}
 
 	for name, response := range s.spec.Responses {
-		refPref := slashpath.Join("/responses", jsonpointer.Escape(name))
-		for k, v := range response.Headers {
-			hRefPref := slashpath.Join(refPref, "headers", k)
-			if v.Items != nil {
-				s.analyzeItems("items", v.Items, hRefPref, "header")
-			}
-			if v.Pattern != "" {
-				s.patterns.addHeaderPattern(hRefPref, v.Pattern)
-			}
-			if len(v.Enum) > 0 {
-				s.enums.addHeaderEnum(hRefPref, v.Enum)
-			}
-		}
-		if response.Schema != nil {
-			s.analyzeSchema("schema", response.Schema, refPref)
-		}
+		s.analyzeSharedResponse(name, response)
 	}
 
 	for name := range s.spec.Definitions {
 		schema := s.spec.Definitions[name]
 		s.analyzeSchema(name, &schema, "/definitions")
 	}
+
+	s.analyzeMediaAndSecurity()
 	// TODO: after analyzing all things and flattening schemas etc
 	// resolve all the collected references to their final representations
 	// best put in a separate method because this could get expensive
 }
 
+// analyzeMediaAndSecurity collects the distinct consumes, produces and security schemes
+// declared at the top level of the spec or by any analyzed operation.
+func (s *Spec) analyzeMediaAndSecurity() {
+	clear(s.consumes)
+	clear(s.produces)
+	clear(s.authSchemes)
+
+	s.addMediaAndSecurity(s.spec.Consumes, s.spec.Produces, s.spec.Security)
+	for _, pathItem := range s.operations {
+		for _, op := range pathItem {
+			s.addMediaAndSecurity(op.Consumes, op.Produces, op.Security)
+		}
+	}
+}
+
+func (s *Spec) addMediaAndSecurity(consumes, produces []string, security []map[string][]string) {
+	for _, c := range consumes {
+		s.consumes[c] = struct{}{}
+	}
+
+	for _, c := range produces {
+		s.produces[c] = struct{}{}
+	}
+
+	for _, ss := range security {
+		for k := range ss {
+			s.authSchemes[k] = struct{}{}
+		}
+	}
+}
+
+func (s *Spec) analyzeSharedParameter(name string, parameter spec.Parameter) {
+	refPref := slashpath.Join("/parameters", jsonpointer.Escape(name))
+	if len(parameter.Extensions) > 0 {
+		s.extensions.addParameterExtensions(refPref, parameter.Extensions)
+	}
+	if parameter.Items != nil {
+		s.analyzeItems("items", parameter.Items, refPref, "parameter")
+	}
+	if parameter.In == "body" && parameter.Schema != nil {
+		s.analyzeSchema("schema", parameter.Schema, refPref)
+	}
+	if parameter.Pattern != "" {
+		s.patterns.addParameterPattern(refPref, parameter.Pattern)
+	}
+	if len(parameter.Enum) > 0 {
+		s.enums.addParameterEnum(refPref, parameter.Enum)
+	}
+}
+
+func (s *Spec) analyzeSharedResponse(name string, response spec.Response) {
+	refPref := slashpath.Join("/responses", jsonpointer.Escape(name))
+	if len(response.Extensions) > 0 {
+		s.extensions.addResponseExtensions(refPref, response.Extensions)
+	}
+	for k, v := range response.Headers {
+		hRefPref := slashpath.Join(refPref, "headers", k)
+		if len(v.Extensions) > 0 {
+			s.extensions.addHeaderExtensions(hRefPref, v.Extensions)
+		}
+		if v.Items != nil {
+			s.analyzeItems("items", v.Items, hRefPref, "header")
+		}
+		if v.Pattern != "" {
+			s.patterns.addHeaderPattern(hRefPref, v.Pattern)
+		}
+		if len(v.Enum) > 0 {
+			s.enums.addHeaderEnum(hRefPref, v.Enum)
+		}
+	}
+	if response.Schema != nil {
+		s.analyzeSchema("schema", response.Schema, refPref)
+	}
+}
+
 func (s *Spec) analyzeOperations(path string, pi *spec.PathItem) {
 	// TODO: resolve refs here?
 	// Currently, operations declared via pathItem $ref are known only after expansion
 	op := pi
+	key := slashpath.Join("/paths", jsonpointer.Escape(path))
 	if pi.Ref.String() != "" {
-		key := slashpath.Join("/paths", jsonpointer.Escape(path))
 		s.references.addPathItemRef(key, pi)
 	}
+	if len(pi.Extensions) > 0 {
+		s.extensions.addPathItemExtensions(key, pi.Extensions)
+	}
 	s.analyzeOperation("GET", path, op.Get)
 	s.analyzeOperation("PUT", path, op.Put)
 	s.analyzeOperation("POST", path, op.Post)
@@ -818,22 +1123,30 @@ func (s *Spec) analyzeOperations(path string, pi *spec.PathItem) {
 	s.analyzeOperation("HEAD", path, op.Head)
 	s.analyzeOperation("OPTIONS", path, op.Options)
 	for i, param := range op.Parameters {
-		refPref := slashpath.Join("/paths", jsonpointer.Escape(path), "parameters", strconv.Itoa(i))
-		if param.Ref.String() != "" {
-			s.references.addParamRef(refPref, &param) //#nosec
-		}
-		if param.Pattern != "" {
-			s.patterns.addParameterPattern(refPref, param.Pattern)
-		}
-		if len(param.Enum) > 0 {
-			s.enums.addParameterEnum(refPref, param.Enum)
-		}
-		if param.Items != nil {
-			s.analyzeItems("items", param.Items, refPref, "parameter")
-		}
-		if param.Schema != nil {
-			s.analyzeSchema("schema", param.Schema, refPref)
-		}
+		s.analyzePathItemParameter(path, i, param)
+	}
+}
+
+func (s *Spec) analyzePathItemParameter(path string, i int, param spec.Parameter) {
+	refPref := slashpath.Join("/paths", jsonpointer.Escape(path), "parameters", strconv.Itoa(i))
+	s.usages.addRoot(refPref, UsageRequest)
+	if param.Ref.String() != "" {
+		s.references.addParamRef(refPref, &param) //#nosec
+	}
+	if len(param.Extensions) > 0 {
+		s.extensions.addParameterExtensions(refPref, param.Extensions)
+	}
+	if param.Pattern != "" {
+		s.patterns.addParameterPattern(refPref, param.Pattern)
+	}
+	if len(param.Enum) > 0 {
+		s.enums.addParameterEnum(refPref, param.Enum)
+	}
+	if param.Items != nil {
+		s.analyzeItems("items", param.Items, refPref, "parameter")
+	}
+	if param.Schema != nil {
+		s.analyzeSchema("schema", param.Schema, refPref)
 	}
 }
 
@@ -846,6 +1159,9 @@ func (s *Spec) analyzeItems(name string, items *spec.Items, prefix, location str
 	if items.Ref.String() != "" {
 		s.references.addItemsRef(refPref, items, location)
 	}
+	if len(items.Extensions) > 0 {
+		s.extensions.addItemsExtensions(refPref, items.Extensions)
+	}
 	if items.Pattern != "" {
 		s.patterns.addItemsPattern(refPref, items.Pattern)
 	}
@@ -856,9 +1172,13 @@ func (s *Spec) analyzeItems(name string, items *spec.Items, prefix, location str
 
 func (s *Spec) analyzeParameter(prefix string, i int, param spec.Parameter) {
 	refPref := slashpath.Join(prefix, "parameters", strconv.Itoa(i))
+	s.usages.addRoot(refPref, UsageRequest)
 	if param.Ref.String() != "" {
 		s.references.addParamRef(refPref, &param) //#nosec
 	}
+	if len(param.Extensions) > 0 {
+		s.extensions.addParameterExtensions(refPref, param.Extensions)
+	}
 
 	if param.Pattern != "" {
 		s.patterns.addParameterPattern(refPref, param.Pattern)
@@ -879,26 +1199,16 @@ func (s *Spec) analyzeOperation(method, path string, op *spec.Operation) {
 		return
 	}
 
-	for _, c := range op.Consumes {
-		s.consumes[c] = struct{}{}
-	}
-
-	for _, c := range op.Produces {
-		s.produces[c] = struct{}{}
-	}
-
-	for _, ss := range op.Security {
-		for k := range ss {
-			s.authSchemes[k] = struct{}{}
-		}
-	}
-
 	if _, ok := s.operations[method]; !ok {
 		s.operations[method] = make(map[string]*spec.Operation)
 	}
 
 	s.operations[method][path] = op
 	prefix := slashpath.Join("/paths", jsonpointer.Escape(path), strings.ToLower(method))
+	if len(op.Extensions) > 0 {
+		s.extensions.addOperationExtensions(prefix, op.Extensions)
+	}
+
 	for i, param := range op.Parameters {
 		s.analyzeParameter(prefix, i, param)
 	}
@@ -918,12 +1228,20 @@ func (s *Spec) analyzeOperation(method, path string, op *spec.Operation) {
 
 func (s *Spec) analyzeDefaultResponse(prefix string, res *spec.Response) {
 	refPref := slashpath.Join(prefix, "responses", "default")
+	s.usages.addRoot(refPref, UsageResponse)
 	if res.Ref.String() != "" {
 		s.references.addResponseRef(refPref, res)
 	}
 
+	if len(res.Extensions) > 0 {
+		s.extensions.addResponseExtensions(refPref, res.Extensions)
+	}
+
 	for k, v := range res.Headers {
 		hRefPref := slashpath.Join(refPref, "headers", k)
+		if len(v.Extensions) > 0 {
+			s.extensions.addHeaderExtensions(hRefPref, v.Extensions)
+		}
 		s.analyzeItems("items", v.Items, hRefPref, "header")
 		if v.Pattern != "" {
 			s.patterns.addHeaderPattern(hRefPref, v.Pattern)
@@ -937,12 +1255,20 @@ func (s *Spec) analyzeDefaultResponse(prefix string, res *spec.Response) {
 
 func (s *Spec) analyzeResponse(prefix string, k int, res spec.Response) {
 	refPref := slashpath.Join(prefix, "responses", strconv.Itoa(k))
+	s.usages.addRoot(refPref, UsageResponse)
 	if res.Ref.String() != "" {
 		s.references.addResponseRef(refPref, &res) //#nosec
 	}
 
+	if len(res.Extensions) > 0 {
+		s.extensions.addResponseExtensions(refPref, res.Extensions)
+	}
+
 	for k, v := range res.Headers {
 		hRefPref := slashpath.Join(refPref, "headers", k)
+		if len(v.Extensions) > 0 {
+			s.extensions.addHeaderExtensions(hRefPref, v.Extensions)
+		}
 		s.analyzeItems("items", v.Items, hRefPref, "header")
 		if v.Pattern != "" {
 			s.patterns.addHeaderPattern(hRefPref, v.Pattern)
@@ -981,6 +1307,10 @@ func (s *Spec) analyzeSchema(name string, schema *spec.Schema, prefix string) {
 		s.enums.addSchemaEnum(refURI, schema.Enum)
 	}
 
+	if len(schema.Extensions) > 0 {
+		s.extensions.addSchemaExtensions(refURI, schema.Extensions)
+	}
+
 	for k, v := range schema.Definitions {
 		s.analyzeSchema(k, &v, slashpath.Join(refURI, "definitions"))
 	}
@@ -1046,6 +1376,16 @@ func (s *Spec) analyzeSchema(name string, schema *spec.Schema, prefix string) {
 	}
 }
 
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	return keys
+}
+
 func cloneStringMap(source map[string]string) map[string]string {
 	res := make(map[string]string, len(source))
 	maps.Copy(res, source)
@@ -1059,3 +1399,12 @@ func cloneEnumMap(source map[string][]any) map[string][]any {
 
 	return res
 }
+
+func cloneExtensionsMap(source map[string]spec.Extensions) map[string]spec.Extensions {
+	res := make(map[string]spec.Extensions, len(source))
+	for key, extensions := range source {
+		res[key] = maps.Clone(extensions)
+	}
+
+	return res
+}
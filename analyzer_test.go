@@ -847,6 +847,52 @@ func TestAnalyzer_EnumAnalysis(t *testing.T) {
 	assert.Lenf(t, res, 2, "Expected 2 items enums in this spec, but got %d", len(res))
 }
 
+func TestAnalyzer_ExtensionAnalysis(t *testing.T) {
+	t.Parallel()
+
+	doc := antest.LoadOrFail(t, filepath.Join("fixtures", "extensions.yml"))
+
+	an := New(doc)
+	ex := an.extensions
+
+	assert.Equal(t, spec.Extensions{"x-go-name": "ID"}, ex.parameters["#/parameters/idParam"])
+	assert.Equal(t, spec.Extensions{"x-internal": true}, ex.responses["#/responses/notFound"])
+	assert.Equal(t, spec.Extensions{"x-go-name": "DefaultError"},
+		ex.responses["#/paths/~1things~1{id}/get/responses/default"])
+	assert.Equal(t, spec.Extensions{"x-omitempty": true}, ex.headers["#/responses/notFound/headers/X-Retry"])
+	assert.Equal(t, spec.Extensions{"x-nullable": true}, ex.items["#/responses/notFound/headers/X-Retry/items"])
+	assert.Equal(t, spec.Extensions{"x-nullable": false}, ex.items["#/paths/~1things~1{id}/get/parameters/0/items"])
+	assert.Equal(t, spec.Extensions{"x-internal": true}, ex.operations["#/paths/~1things~1{id}/get"])
+	assert.Equal(t, spec.Extensions{"X-Internal": false}, ex.pathItems["#/paths/~1things~1{id}"])
+	assert.Equal(t, spec.Extensions{"x-go-name": "Thing"}, ex.schemas["#/definitions/thing"])
+
+	assert.Len(t, an.AllExtensions(), 10)
+	assert.Len(t, an.SchemaExtensions(), 2)
+	assert.Len(t, an.ParameterExtensions(), 1)
+	assert.Len(t, an.HeaderExtensions(), 1)
+	assert.Len(t, an.ItemsExtensions(), 2)
+	assert.Len(t, an.OperationExtensions(), 1)
+	assert.Len(t, an.PathItemExtensions(), 1)
+	assert.Len(t, an.ResponseExtensions(), 2)
+
+	assert.Equal(t, map[string]any{
+		"#/responses/notFound":       true,
+		"#/paths/~1things~1{id}/get": true,
+		"#/paths/~1things~1{id}":     false,
+	}, an.ExtensionsNamed("X-Internal"))
+	assert.Equal(t, map[string]any{
+		"#/responses/notFound/headers/X-Retry/items":    true,
+		"#/paths/~1things~1{id}/get/parameters/0/items": false,
+		"#/definitions/thing/properties/name":           true,
+	}, an.ExtensionsNamed("x-nullable"))
+	assert.Len(t, an.ExtensionsNamed("x-internal"), 3)
+	assert.Empty(t, an.ExtensionsNamed("x-isnullable"))
+
+	// returned extensions are cloned
+	an.SchemaExtensions()["#/definitions/thing"]["x-go-name"] = "Other"
+	assert.Equal(t, "Thing", doc.Definitions["thing"].Extensions["x-go-name"])
+}
+
 /* helpers for the Analyzer test suite */
 
 func schemeNames(schemes [][]SecurityRequirement) []string {
@@ -1018,3 +1064,81 @@ func prepareTestParamsInvalid(t testing.TB, fixture string) *Spec {
 
 	return analyzer
 }
+
+func TestAnalyzer_ParamDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	doc := antest.LoadOrFail(t, filepath.Join("fixtures", "param-diagnostics.yml"))
+	an := New(doc, WithParamDiagnostics())
+
+	diagnose := func(method string) []string {
+		var diagnostics []string
+		_ = an.SafeParamsFor(method, "/items/{id}", func(_ spec.Parameter, err error) bool {
+			require.ErrorIs(t, err, ErrParamDiagnostic)
+			diagnostics = append(diagnostics, err.Error())
+
+			return true
+		})
+
+		return diagnostics
+	}
+
+	t.Run("with incompatible overrides and duplicates", func(t *testing.T) {
+		diagnostics := diagnose("GET")
+		require.Len(t, diagnostics, 3)
+		assert.StringContainsT(t, diagnostics[0], `parameter "path#ID" of type "string" overrides a path parameter of type "integer"`)
+		assert.StringContainsT(t, diagnostics[1], `parameter "query#Tags" of type "array[integer]" overrides a path parameter of type "array[string]"`)
+		assert.StringContainsT(t, diagnostics[2], `duplicate parameter "query#Limit"`)
+	})
+
+	t.Run("with body parameters", func(t *testing.T) {
+		diagnostics := diagnose("PUT")
+		require.Len(t, diagnostics, 2)
+		assert.StringContainsT(t, diagnostics[0], "multiple body parameters: [item other]")
+		assert.StringContainsT(t, diagnostics[1], `body parameter "item" cannot be combined with formData parameter "file"`)
+	})
+
+	t.Run("with path parameters only", func(t *testing.T) {
+		assert.Empty(t, diagnose("DELETE"))
+	})
+
+	t.Run("with all operations", func(t *testing.T) {
+		var count int
+		_ = an.SafeParametersFor("getItem", func(_ spec.Parameter, err error) bool {
+			require.ErrorIs(t, err, ErrParamDiagnostic)
+			count++
+
+			return true
+		})
+		assert.EqualT(t, 3, count)
+	})
+
+	t.Run("should bail out", func(t *testing.T) {
+		var count int
+		_ = an.SafeParamsFor("PUT", "/items/{id}", func(spec.Parameter, error) bool {
+			count++
+
+			return false
+		})
+		assert.EqualT(t, 1, count)
+	})
+
+	t.Run("should ignore diagnostics without callback", func(t *testing.T) {
+		assert.NotPanics(t, func() {
+			assert.Len(t, an.ParamsFor("GET", "/items/{id}"), 3)
+			assert.Len(t, an.ParametersFor("putItem"), 5)
+			for view := range an.OperationViews() {
+				assert.NotEmpty(t, view.Parameters)
+			}
+		})
+	})
+
+	t.Run("should not report without the option", func(t *testing.T) {
+		params := New(doc).SafeParamsFor("PUT", "/items/{id}", func(_ spec.Parameter, err error) bool {
+			require.Fail(t, "unexpected error", err)
+
+			return true
+		})
+		assert.Len(t, params, 5)
+	})
+}
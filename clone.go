@@ -0,0 +1,162 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"reflect"
+
+	"github.com/go-openapi/spec"
+)
+
+// FlattenResult holds a flattened copy of a spec, produced by [FlattenCopy].
+type FlattenResult struct {
+	// Swagger is the flattened copy of the swagger document.
+	Swagger *spec.Swagger
+
+	// Spec is the analysis of the flattened copy.
+	Spec *Spec
+
+	FlattenReport
+}
+
+// FlattenCopy flattens a copy of an analyzed spec like [FlattenWithReport], leaving opts.Spec and
+// its underlying swagger document untouched.
+//
+// The copy is a structural clone of the document: no serialization round trip is involved.
+// Since the original spec is not altered, it may be a [Snapshot].
+//
+// Nothing is logged, regardless of the Verbose option.
+func FlattenCopy(opts FlattenOpts) (*FlattenResult, error) {
+	if opts.Spec == nil {
+		return nil, ErrNoSpec
+	}
+
+	doc := cloneSwagger(opts.Spec.spec)
+	opts.Spec = opts.Spec.analyzeCopy(doc, false)
+
+	report, err := FlattenWithReport(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FlattenResult{
+		Swagger:       doc,
+		Spec:          opts.Spec,
+		FlattenReport: *report,
+	}, nil
+}
+
+// analyzeCopy analyzes a copy of the swagger document with the same options as this spec.
+func (s *Spec) analyzeCopy(doc *spec.Swagger, readOnly bool) *Spec {
+	a := &Spec{
+		spec:     doc,
+		mangler:  s.mangler,
+		readOnly: readOnly,
+
+		paramDiagnostics: s.paramDiagnostics,
+		positions:        s.positions,
+	}
+	a.reset()
+	a.initialize()
+
+	return a
+}
+
+// cloneSwagger yields a deep copy of a swagger document, without resorting to a serialization round trip.
+//
+// [spec.Ref] values are immutable and are shared by the copy.
+func cloneSwagger(doc *spec.Swagger) *spec.Swagger {
+	c := cloner{visited: make(map[visitedPointer]reflect.Value, allocLargeMap)}
+
+	return c.clone(reflect.ValueOf(doc)).Interface().(*spec.Swagger) //nolint:forcetypeassert // the clone has the type of the original
+}
+
+var refType = reflect.TypeFor[spec.Ref]() //nolint:gochecknoglobals // quasi-constant
+
+type visitedPointer struct {
+	address uintptr
+	typ     reflect.Type
+}
+
+// cloner deep-copies values by reflection.
+//
+// Pointers shared by the original are shared by the copy, so cycles are preserved.
+// Unexported fields are copied as is, i.e. shallowly. In a swagger document, the only unexported fields
+// are those of [spec.Ref] (its parsed *url.URL): this is safe only because a spec.Ref is never mutated
+// in place, but always replaced by a new one.
+type cloner struct {
+	visited map[visitedPointer]reflect.Value
+}
+
+func (c *cloner) clone(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+
+		key := visitedPointer{address: v.Pointer(), typ: v.Type()}
+		if cloned, ok := c.visited[key]; ok {
+			return cloned
+		}
+
+		cloned := reflect.New(v.Type().Elem())
+		c.visited[key] = cloned
+		cloned.Elem().Set(c.clone(v.Elem()))
+
+		return cloned
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+
+		cloned := reflect.New(v.Type()).Elem()
+		cloned.Set(c.clone(v.Elem()))
+
+		return cloned
+
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+
+		cloned := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			cloned.SetMapIndex(iter.Key(), c.clone(iter.Value()))
+		}
+
+		return cloned
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+
+		cloned := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := range v.Len() {
+			cloned.Index(i).Set(c.clone(v.Index(i)))
+		}
+
+		return cloned
+
+	case reflect.Struct:
+		if v.Type() == refType {
+			return v
+		}
+
+		cloned := reflect.New(v.Type()).Elem()
+		cloned.Set(v)
+		for i := range v.NumField() {
+			if field := cloned.Field(i); field.CanSet() {
+				field.Set(c.clone(v.Field(i)))
+			}
+		}
+
+		return cloned
+
+	default:
+		return v
+	}
+}
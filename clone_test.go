@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/analysis/internal/antest"
+	"github.com/go-openapi/spec"
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestCloneSwagger(t *testing.T) {
+	t.Parallel()
+
+	doc := antest.LoadOrFail(t, filepath.Join("fixtures", "flatten.yml"))
+	clone := cloneSwagger(doc)
+
+	require.Equal(t, doc, clone)
+	assert.JSONEqT(t, antest.AsJSON(t, doc), antest.AsJSON(t, clone))
+
+	t.Run("should not share mutable parts", func(t *testing.T) {
+		original := antest.AsJSON(t, doc)
+
+		clone.Definitions["datedTag"].AllOf[0].Format = "date-time"
+		clone.Paths.Paths["/some/where/{id}"].Get.Parameters[0].Name = "changed"
+		clone.AddExtension("x-new", true)
+		clone.Info.Title = "changed"
+
+		assert.JSONEqT(t, original, antest.AsJSON(t, doc))
+	})
+
+	t.Run("should preserve shared pointers", func(t *testing.T) {
+		schema := spec.StringProperty()
+		shared := &spec.Swagger{SwaggerProps: spec.SwaggerProps{
+			Definitions: spec.Definitions{
+				"a": {SchemaProps: spec.SchemaProps{Items: &spec.SchemaOrArray{Schema: schema}}},
+				"b": {SchemaProps: spec.SchemaProps{Not: schema}},
+			},
+		}}
+
+		cloned := cloneSwagger(shared)
+		assert.NotSame(t, schema, cloned.Definitions["a"].Items.Schema)
+		assert.Same(t, cloned.Definitions["a"].Items.Schema, cloned.Definitions["b"].Not)
+	})
+}
+
+func TestFlattenCopy(t *testing.T) {
+	t.Parallel()
+
+	bp := filepath.Join("fixtures", "flatten.yml")
+
+	t.Run("should flatten a copy", func(t *testing.T) {
+		t.Parallel()
+
+		doc := antest.LoadOrFail(t, bp)
+		original := antest.AsJSON(t, doc)
+		an := New(doc)
+
+		result, err := FlattenCopy(FlattenOpts{Spec: an, BasePath: bp, RemoveUnused: true})
+		require.NoError(t, err)
+		require.NotNil(t, result)
+
+		assert.JSONEqT(t, original, antest.AsJSON(t, doc), "the original document should be untouched")
+		assert.ElementsMatch(t, New(doc).AllReferences(), an.AllReferences(), "the original analysis should be untouched")
+
+		expected := antest.LoadOrFail(t, bp)
+		require.NoError(t, Flatten(FlattenOpts{Spec: New(expected), BasePath: bp, RemoveUnused: true}))
+		assert.JSONEqT(t, antest.AsJSON(t, expected), antest.AsJSON(t, result.Swagger))
+
+		assert.NotEmpty(t, result.Changes)
+		assert.ElementsMatch(t, New(result.Swagger).AllReferences(), result.Spec.AllReferences())
+		_, ok := result.Spec.spec.Definitions["getSomeWhereIdOKBody"]
+		assert.TrueT(t, ok)
+	})
+
+	t.Run("should flatten a copy of a snapshot", func(t *testing.T) {
+		t.Parallel()
+
+		snapshot := New(antest.LoadOrFail(t, bp)).Snapshot()
+		original := antest.AsJSON(t, snapshot.Swagger())
+
+		result, err := FlattenCopy(FlattenOpts{Spec: snapshot.Spec, BasePath: bp, Minimal: true})
+		require.NoError(t, err)
+		assert.JSONEqT(t, original, antest.AsJSON(t, snapshot.Swagger()))
+		assert.NotEqual(t, original, antest.AsJSON(t, result.Swagger))
+
+		t.Run("the copy should be mutable", func(t *testing.T) {
+			require.NoError(t, Flatten(FlattenOpts{Spec: result.Spec, BasePath: bp}))
+		})
+	})
+
+	t.Run("should fail without a spec", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := FlattenCopy(FlattenOpts{BasePath: bp})
+		require.ErrorIs(t, err, ErrNoSpec)
+	})
+}
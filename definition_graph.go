@@ -0,0 +1,303 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strings"
+)
+
+// DefinitionEdgeKind qualifies how a definition depends on another one.
+type DefinitionEdgeKind string
+
+const (
+	EdgeRef                  DefinitionEdgeKind = "$ref"
+	EdgeAllOf                DefinitionEdgeKind = "allOf"
+	EdgeAnyOf                DefinitionEdgeKind = "anyOf"
+	EdgeOneOf                DefinitionEdgeKind = "oneOf"
+	EdgeNot                  DefinitionEdgeKind = "not"
+	EdgeItems                DefinitionEdgeKind = "items"
+	EdgeAdditionalItems      DefinitionEdgeKind = "additionalItems"
+	EdgeProperty             DefinitionEdgeKind = "property"
+	EdgeAdditionalProperties DefinitionEdgeKind = "additionalProperties"
+)
+
+// DefinitionEdge is a dependency from one definition to another, materialized by a $ref.
+type DefinitionEdge struct {
+	From string             // name of the definition holding the $ref
+	To   string             // name of the definition the $ref points to
+	Kind DefinitionEdgeKind // the construct in which the $ref is found
+	Key  string             // location of the $ref, e.g. "#/definitions/Pet/properties/tags/items"
+}
+
+// DefinitionGraph is the dependency graph of the definitions found in a spec.
+//
+// Nodes are the names of the definitions under "#/definitions". Edges are the $ref found in these definitions
+// which point to another definition (or to some location nested in another definition).
+//
+// Nodes and edges are sorted in lexicographic order.
+type DefinitionGraph struct {
+	Nodes []string
+	Edges []DefinitionEdge
+}
+
+// DefinitionGraph builds the dependency graph of the definitions in the analyzed spec.
+//
+// Remote $ref are not followed: the spec should be flattened first to get a complete graph.
+// Malformed $ref and $ref to missing definitions yield no edge.
+func (s *Spec) DefinitionGraph() *DefinitionGraph {
+	g := &DefinitionGraph{
+		Nodes: make([]string, 0, len(s.spec.Definitions)),
+	}
+
+	for name := range s.spec.Definitions {
+		g.Nodes = append(g.Nodes, name)
+	}
+	slices.Sort(g.Nodes)
+
+	for key, ref := range s.references.schemas {
+		from := pointerParts(key)
+		if len(from) < 2 || from[0] != "definitions" { //nolint:mnd // a definition requires 2 parts
+			continue
+		}
+
+		target, ok := enclosingDefinitionName(ref.String())
+		if !ok {
+			continue
+		}
+		if _, ok := s.spec.Definitions[target]; !ok {
+			continue
+		}
+
+		g.Edges = append(g.Edges, DefinitionEdge{
+			From: from[1],
+			To:   target,
+			Kind: edgeKindFromParts(from[2:]),
+			Key:  key,
+		})
+	}
+
+	slices.SortFunc(g.Edges, func(a, b DefinitionEdge) int {
+		return cmp.Or(cmp.Compare(a.From, b.From), cmp.Compare(a.To, b.To), cmp.Compare(a.Key, b.Key))
+	})
+
+	return g
+}
+
+// StronglyConnectedComponents returns the strongly connected components of the graph.
+//
+// Each component is sorted, and components are sorted by their first node.
+func (g *DefinitionGraph) StronglyConnectedComponents() [][]string {
+	successors := make(map[string][]string, len(g.Nodes))
+	for _, edge := range g.Edges {
+		successors[edge.From] = append(successors[edge.From], edge.To)
+	}
+
+	// Tarjan's algorithm
+	var (
+		index      int
+		stack      []string
+		components [][]string
+		visit      func(string)
+	)
+	indices := make(map[string]int, len(g.Nodes))
+	lowLinks := make(map[string]int, len(g.Nodes))
+	onStack := make(map[string]bool, len(g.Nodes))
+
+	visit = func(node string) {
+		indices[node] = index
+		lowLinks[node] = index
+		index++
+		stack = append(stack, node)
+		onStack[node] = true
+
+		for _, next := range successors[node] {
+			if _, visited := indices[next]; !visited {
+				visit(next)
+				lowLinks[node] = min(lowLinks[node], lowLinks[next])
+			} else if onStack[next] {
+				lowLinks[node] = min(lowLinks[node], indices[next])
+			}
+		}
+
+		if lowLinks[node] != indices[node] {
+			return
+		}
+
+		var component []string
+		for {
+			last := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[last] = false
+			component = append(component, last)
+
+			if last == node {
+				break
+			}
+		}
+		slices.Sort(component)
+		components = append(components, component)
+	}
+
+	for _, node := range g.Nodes {
+		if _, visited := indices[node]; !visited {
+			visit(node)
+		}
+	}
+
+	slices.SortFunc(components, func(a, b []string) int {
+		return cmp.Compare(a[0], b[0])
+	})
+
+	return components
+}
+
+// Cycles returns the groups of mutually recursive definitions, i.e. the strongly connected components
+// with more than one definition, or with a single definition that refers to itself.
+func (g *DefinitionGraph) Cycles() [][]string {
+	selfLoops := make(map[string]bool)
+	for _, edge := range g.Edges {
+		if edge.From == edge.To {
+			selfLoops[edge.From] = true
+		}
+	}
+
+	components := g.StronglyConnectedComponents()
+	cycles := make([][]string, 0, len(components))
+	for _, component := range components {
+		if len(component) > 1 || selfLoops[component[0]] {
+			cycles = append(cycles, component)
+		}
+	}
+
+	return cycles
+}
+
+// IsRecursive tells if a definition belongs to some cycle.
+func (g *DefinitionGraph) IsRecursive(name string) bool {
+	for _, cycle := range g.Cycles() {
+		if slices.Contains(cycle, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WriteDOT renders the graph in the graphviz DOT language.
+//
+// Definitions which belong to a cycle are rendered in bold.
+func (g *DefinitionGraph) WriteDOT(w io.Writer) error {
+	recursive := g.recursiveNodes()
+
+	var b strings.Builder
+	b.WriteString("digraph definitions {\n")
+	for _, node := range g.Nodes {
+		if recursive[node] {
+			fmt.Fprintf(&b, "  %s [style=bold];\n", dotQuote(node))
+
+			continue
+		}
+
+		fmt.Fprintf(&b, "  %s;\n", dotQuote(node))
+	}
+
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&b, "  %s -> %s [label=%s];\n", dotQuote(edge.From), dotQuote(edge.To), dotQuote(string(edge.Kind)))
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+// WriteMermaid renders the graph as a mermaid flowchart.
+//
+// Definitions which belong to a cycle are assigned the "recursive" class.
+func (g *DefinitionGraph) WriteMermaid(w io.Writer) error {
+	recursive := g.recursiveNodes()
+	ids := make(map[string]string, len(g.Nodes))
+
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for i, node := range g.Nodes {
+		id := fmt.Sprintf("n%d", i)
+		ids[node] = id
+		fmt.Fprintf(&b, "  %s[\"%s\"]\n", id, mermaidEscape(node))
+	}
+
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&b, "  %s -->|%s| %s\n", ids[edge.From], mermaidEscape(string(edge.Kind)), ids[edge.To])
+	}
+
+	if len(recursive) > 0 {
+		b.WriteString("  classDef recursive font-weight:bold\n")
+	}
+
+	for _, node := range g.Nodes {
+		if recursive[node] {
+			fmt.Fprintf(&b, "  class %s recursive\n", ids[node])
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+func (g *DefinitionGraph) recursiveNodes() map[string]bool {
+	recursive := make(map[string]bool, len(g.Nodes))
+	for _, cycle := range g.Cycles() {
+		for _, node := range cycle {
+			recursive[node] = true
+		}
+	}
+
+	return recursive
+}
+
+// edgeKindFromParts determines the construct holding a $ref from the parts of its location,
+// relative to the definition.
+func edgeKindFromParts(parts []string) DefinitionEdgeKind {
+	kind := EdgeRef
+	for i := 0; i < len(parts); {
+		switch part := parts[i]; part {
+		case "properties", "patternProperties":
+			kind = EdgeProperty
+			i += 2
+		case "definitions":
+			kind = EdgeRef
+			i += 2
+		case "allOf", "anyOf", "oneOf":
+			kind = DefinitionEdgeKind(part)
+			i += 2
+		case "items":
+			kind = EdgeItems
+			i++
+			if _, isTuple := indexPart(parts[i:], math.MaxInt); isTuple {
+				i++
+			}
+		case "not", "additionalItems", "additionalProperties":
+			kind = DefinitionEdgeKind(part)
+			i++
+		default:
+			i++
+		}
+	}
+
+	return kind
+}
+
+func dotQuote(in string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(in) + `"`
+}
+
+func mermaidEscape(in string) string {
+	return strings.ReplaceAll(in, `"`, "#quot;")
+}
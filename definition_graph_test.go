@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestDefinitionGraph(t *testing.T) {
+	t.Parallel()
+
+	sp := &spec.Swagger{
+		SwaggerProps: spec.SwaggerProps{
+			Swagger: "2.0",
+			Definitions: spec.Definitions{
+				"pet": *spec.MapProperty(nil).
+					SetProperty("owner", *spec.RefSchema("#/definitions/person")).
+					SetProperty("items", *spec.RefSchema("#/definitions/tag")),
+				"person": *spec.ArrayProperty(spec.RefSchema("#/definitions/pet")),
+				"node": {SchemaProps: spec.SchemaProps{
+					AllOf: []spec.Schema{*spec.RefSchema("#/definitions/tag")},
+					AdditionalProperties: &spec.SchemaOrBool{
+						Schema: spec.RefSchema("#/definitions/node"),
+					},
+				}},
+				"tag":   *spec.StringProperty(),
+				"alias": *spec.RefSchema("#/definitions/tag/properties/name"),
+				"other": *spec.RefSchema("other.yaml#/definitions/remote"),
+			},
+		},
+	}
+
+	g := New(sp).DefinitionGraph()
+
+	assert.Equal(t, []string{"alias", "node", "other", "person", "pet", "tag"}, g.Nodes)
+	assert.Equal(t, []DefinitionEdge{
+		{From: "alias", To: "tag", Kind: EdgeRef, Key: "#/definitions/alias"},
+		{From: "node", To: "node", Kind: EdgeAdditionalProperties, Key: "#/definitions/node/additionalProperties"},
+		{From: "node", To: "tag", Kind: EdgeAllOf, Key: "#/definitions/node/allOf/0"},
+		{From: "person", To: "pet", Kind: EdgeItems, Key: "#/definitions/person/items"},
+		{From: "pet", To: "person", Kind: EdgeProperty, Key: "#/definitions/pet/properties/owner"},
+		{From: "pet", To: "tag", Kind: EdgeProperty, Key: "#/definitions/pet/properties/items"},
+	}, g.Edges)
+
+	assert.Equal(t, [][]string{
+		{"alias"}, {"node"}, {"other"}, {"person", "pet"}, {"tag"},
+	}, g.StronglyConnectedComponents())
+
+	assert.Equal(t, [][]string{{"node"}, {"person", "pet"}}, g.Cycles())
+	assert.True(t, g.IsRecursive("pet"))
+	assert.False(t, g.IsRecursive("tag"))
+
+	t.Run("should render DOT", func(t *testing.T) {
+		var b strings.Builder
+		require.NoError(t, g.WriteDOT(&b))
+
+		dot := b.String()
+		assert.True(t, strings.HasPrefix(dot, "digraph definitions {\n"))
+		assert.Contains(t, dot, `  "pet" [style=bold];`)
+		assert.Contains(t, dot, `  "tag";`)
+		assert.Contains(t, dot, `  "pet" -> "person" [label="property"];`)
+		assert.Contains(t, dot, `  "alias" -> "tag" [label="$ref"];`)
+	})
+
+	t.Run("should render mermaid", func(t *testing.T) {
+		var b strings.Builder
+		require.NoError(t, g.WriteMermaid(&b))
+
+		mermaid := b.String()
+		assert.True(t, strings.HasPrefix(mermaid, "flowchart LR\n"))
+		assert.Contains(t, mermaid, `  n4["pet"]`)
+		assert.Contains(t, mermaid, `  n4 -->|property| n3`)
+		assert.Contains(t, mermaid, `  n1 -->|additionalProperties| n1`)
+		assert.Contains(t, mermaid, `  class n4 recursive`)
+		assert.NotContains(t, mermaid, `  class n5 recursive`)
+	})
+
+	t.Run("should skip malformed $ref", func(t *testing.T) {
+		broken := New(&spec.Swagger{
+			SwaggerProps: spec.SwaggerProps{
+				Swagger: "2.0",
+				Definitions: spec.Definitions{
+					"broken": *spec.MapProperty(nil).
+						SetProperty("empty", *spec.RefSchema("#/definitions/")).
+						SetProperty("root", *spec.RefSchema("#/definitions")).
+						SetProperty("missing", *spec.RefSchema("#/definitions/missing")).
+						SetProperty("tag", *spec.RefSchema("#/definitions/tag/")),
+					"tag": *spec.StringProperty(),
+				},
+			},
+		}).DefinitionGraph()
+
+		assert.Equal(t, []string{"broken", "tag"}, broken.Nodes)
+		assert.Equal(t, []DefinitionEdge{
+			{From: "broken", To: "tag", Kind: EdgeProperty, Key: "#/definitions/broken/properties/tag"},
+		}, broken.Edges)
+	})
+}
@@ -3,6 +3,14 @@
 
 package diff
 
+import (
+	slashpath "path"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/jsonpointer"
+)
+
 // DifferenceLocation indicates where the difference occurs.
 type DifferenceLocation struct {
 	URL      string `json:"url"`
@@ -23,3 +31,42 @@ func (dl DifferenceLocation) AddNode(node *Node) DifferenceLocation {
 	}
 	return newLoc
 }
+
+// Pointer yields a JSON pointer to the closest construct of the spec enclosing this location,
+// e.g. "#/paths/~1pets/get/responses/200" or "#/definitions/Pet".
+//
+// It is empty when the location cannot be mapped to a construct.
+func (dl DifferenceLocation) Pointer() string {
+	if dl.URL != "" {
+		parts := []string{"/paths", jsonpointer.Escape(dl.URL)}
+		if dl.Method != "" {
+			parts = append(parts, strings.ToLower(dl.Method))
+		}
+		if dl.Response > 0 {
+			parts = append(parts, "responses", strconv.Itoa(dl.Response))
+		}
+
+		return "#" + slashpath.Join(parts...)
+	}
+
+	if dl.Node == nil {
+		return ""
+	}
+
+	switch dl.Node.Field {
+	case "Spec Definitions":
+		if dl.Node.ChildNode != nil {
+			return "#" + slashpath.Join("/definitions", jsonpointer.Escape(dl.Node.ChildNode.Field))
+		}
+
+		return "#/definitions"
+	case "Spec":
+		if dl.Node.ChildNode != nil {
+			return "#/" + jsonpointer.Escape(dl.Node.ChildNode.Field)
+		}
+
+		return "#"
+	default:
+		return ""
+	}
+}
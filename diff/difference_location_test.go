@@ -18,3 +18,12 @@ func TestDifferenceLocation_AddNode(t *testing.T) {
 	newLocation2 := parentLocation.AddNode(&Node{Field: "child2"})
 	assert.EqualT(t, "child2", newLocation2.Node.ChildNode.Field)
 }
+
+func TestDifferenceLocation_Pointer(t *testing.T) {
+	assert.EqualT(t, "#/paths/~1pets~1{id}/put", DifferenceLocation{URL: "/pets/{id}", Method: "PUT"}.Pointer())
+	assert.EqualT(t, "#/paths/~1pets/get/responses/200", DifferenceLocation{URL: "/pets", Method: "get", Response: 200}.Pointer())
+	assert.EqualT(t, "#/definitions/Pet",
+		DifferenceLocation{Node: &Node{Field: "Spec Definitions", ChildNode: &Node{Field: "Pet"}}}.Pointer())
+	assert.EqualT(t, "#/consumes", DifferenceLocation{Node: &Node{Field: "Spec", ChildNode: &Node{Field: "consumes"}}}.Pointer())
+	assert.Empty(t, DifferenceLocation{}.Pointer())
+}
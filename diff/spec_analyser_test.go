@@ -12,6 +12,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/go-openapi/analysis"
 	"github.com/go-openapi/analysis/internal/antest"
 
 	"github.com/go-openapi/testify/v2/assert"
@@ -129,6 +130,43 @@ func TestIssue2962(t *testing.T) {
 	})
 }
 
+func TestSpecDifferences_WithPositions(t *testing.T) {
+	oldSpec := fixturePath("path", ".v1.json")
+	newSpec := fixturePath("path", ".v2.json")
+
+	diffs, err := getDiffs(oldSpec, newSpec)
+	require.NoError(t, err)
+
+	positions := func(file string) *analysis.PositionMap {
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+
+		m, err := analysis.NewPositionMap(file, data)
+		require.NoError(t, err)
+
+		return m
+	}
+
+	located := diffs.WithPositions(positions(oldSpec), positions(newSpec))
+	require.Len(t, located, len(diffs))
+
+	for _, diff := range located {
+		require.NotNil(t, diff.Position)
+
+		switch diff.DifferenceLocation.URL + ":" + diff.DifferenceLocation.Method {
+		case "/a/:put":
+			// added endpoint: found in the new spec
+			assert.Equal(t, analysis.Position{File: newSpec, Line: 28, Column: 7}, *diff.Position)
+			assert.StringContainsT(t, diff.String(), "at "+newSpec+":28:7")
+		case "/b/:post":
+			// deleted endpoint: found in the old spec
+			assert.Equal(t, analysis.Position{File: oldSpec, Line: 122, Column: 7}, *diff.Position)
+		}
+	}
+
+	assert.Nil(t, diffs[0].Position, "original differences should not be altered")
+}
+
 func fixturePath(file string, parts ...string) string {
 	return filepath.Join("fixtures", strings.Join(append([]string{file}, parts...), ""))
 }
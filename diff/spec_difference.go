@@ -9,6 +9,8 @@ import (
 	"io"
 	"slices"
 	"strings"
+
+	"github.com/go-openapi/analysis"
 )
 
 // SpecDifference encapsulates the details of an individual diff in part of a spec.
@@ -17,6 +19,9 @@ type SpecDifference struct {
 	Code               SpecChangeCode     `json:"code"`
 	Compatibility      Compatibility      `json:"compatibility"`
 	DiffInfo           string             `json:"info,omitempty"`
+
+	// Position of the difference in the source documents, when known (see [SpecDifferences.WithPositions]).
+	Position *analysis.Position `json:"position,omitempty"`
 }
 
 // SpecDifferences list of differences.
@@ -106,6 +111,36 @@ func (sd SpecDifferences) Contains(diff SpecDifference) bool {
 	return false
 }
 
+// WithPositions locates each difference in the source documents of the compared specs.
+//
+// Differences are located in the new spec, or in the old spec for constructs which only exist there
+// (e.g. deleted endpoints). Either map may be nil.
+func (sd SpecDifferences) WithPositions(before, after *analysis.PositionMap) SpecDifferences {
+	located := make(SpecDifferences, 0, len(sd))
+	for _, diff := range sd {
+		diff.Position = nil
+		if pointer := diff.DifferenceLocation.Pointer(); pointer != "" {
+			positions := after
+			if !after.Contains(pointer) && before.Contains(pointer) {
+				positions = before
+			}
+
+			pos, ok := positions.Position(pointer)
+			if !ok {
+				pos, ok = before.Position(pointer)
+			}
+
+			if ok {
+				diff.Position = &pos
+			}
+		}
+
+		located = append(located, diff)
+	}
+
+	return located
+}
+
 // String std string renderer.
 func (sd SpecDifference) String() string {
 	isResponse := sd.DifferenceLocation.Response > 0
@@ -138,8 +173,13 @@ func (sd SpecDifference) String() string {
 		optionalInfo = sd.DiffInfo
 	}
 
+	position := ""
+	if sd.Position != nil {
+		position = "at " + sd.Position.String()
+	}
+
 	items := []string{}
-	for _, item := range []string{prefix, direction, paramOrPropertyLocation, sd.Code.Description(), optionalInfo} {
+	for _, item := range []string{prefix, direction, paramOrPropertyLocation, sd.Code.Description(), optionalInfo, position} {
 		if item != "" {
 			items = append(items, item)
 		}
@@ -0,0 +1,302 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"fmt"
+	slashpath "path"
+	"slices"
+	"strings"
+
+	"github.com/go-openapi/jsonpointer"
+	"github.com/go-openapi/spec"
+)
+
+const discriminatorValueExtension = "x-discriminator-value"
+
+// DiscriminatedType is a definition with a discriminator, i.e. the base type of a polymorphic hierarchy.
+type DiscriminatedType struct {
+	// Name of the base definition.
+	Name string
+
+	// Discriminator is the name of the property which holds the discriminator value.
+	Discriminator string
+
+	// Subtypes of the base type, sorted by name.
+	Subtypes []Subtype
+}
+
+// Subtype is a definition which composes a discriminated type with allOf, either directly or through
+// another subtype.
+type Subtype struct {
+	// Name of the subtype definition.
+	Name string
+
+	// Parent is the name of the definition referred to by the allOf of this subtype.
+	Parent string
+
+	// Value of the discriminator for this subtype: the x-discriminator-value extension if any,
+	// or the name of the definition.
+	Value string
+}
+
+// SubtypeFor returns the subtype matching a discriminator value.
+func (d DiscriminatedType) SubtypeFor(value string) (Subtype, bool) {
+	for _, subtype := range d.Subtypes {
+		if subtype.Value == value {
+			return subtype, true
+		}
+	}
+
+	return Subtype{}, false
+}
+
+// DiscriminatorIssueKind qualifies an issue found with a discriminator.
+type DiscriminatorIssueKind string
+
+const (
+	// DiscriminatorMissingProperty reports a discriminator which is not a declared property of the base type.
+	DiscriminatorMissingProperty DiscriminatorIssueKind = "missing-property"
+
+	// DiscriminatorNotRequired reports a discriminator property which is not required.
+	DiscriminatorNotRequired DiscriminatorIssueKind = "not-required"
+
+	// DiscriminatorNotString reports a discriminator property which is not a string.
+	DiscriminatorNotString DiscriminatorIssueKind = "not-string"
+
+	// DiscriminatorDuplicateValue reports subtypes sharing the same discriminator value.
+	DiscriminatorDuplicateValue DiscriminatorIssueKind = "duplicate-value"
+)
+
+// DiscriminatorIssue reports an issue with the discriminated type located at Key.
+type DiscriminatorIssue struct {
+	// Key is the JSON pointer to the base definition, e.g. "#/definitions/Pet".
+	Key     string
+	Kind    DiscriminatorIssueKind
+	Message string
+}
+
+// DiscriminatedTypes returns all the definitions with a discriminator, along with their subtypes.
+//
+// Subtypes are definitions which refer to the base type (or to another subtype) with an allOf.
+//
+// Discriminated types are sorted by name.
+func (s *Spec) DiscriminatedTypes() []DiscriminatedType {
+	children := s.allOfChildren()
+	var result []DiscriminatedType
+
+	for _, name := range sortedKeys(s.spec.Definitions) {
+		base := s.spec.Definitions[name]
+		if base.Discriminator == "" {
+			continue
+		}
+
+		discriminated := DiscriminatedType{Name: name, Discriminator: base.Discriminator}
+		visited := map[string]struct{}{name: {}}
+		pending := []string{name}
+		for len(pending) > 0 {
+			parent := pending[len(pending)-1]
+			pending = pending[:len(pending)-1]
+
+			for _, child := range children[parent] {
+				if _, ok := visited[child]; ok {
+					continue
+				}
+				visited[child] = struct{}{}
+				pending = append(pending, child)
+
+				discriminated.Subtypes = append(discriminated.Subtypes, Subtype{
+					Name:   child,
+					Parent: parent,
+					Value:  discriminatorValue(child, s.spec.Definitions[child]),
+				})
+			}
+		}
+		slices.SortFunc(discriminated.Subtypes, func(a, b Subtype) int { return strings.Compare(a.Name, b.Name) })
+
+		result = append(result, discriminated)
+	}
+
+	return result
+}
+
+// DiscriminatedTypeFor returns the discriminated type of a definition, if any: either the definition
+// itself when it declares a discriminator, or the discriminated type of the hierarchy it is a subtype of.
+//
+// When a definition is a subtype in several hierarchies, the first one, as sorted by [Spec.DiscriminatedTypes],
+// is returned.
+func (s *Spec) DiscriminatedTypeFor(name string) (DiscriminatedType, bool) {
+	types := s.DiscriminatedTypes()
+	for _, discriminated := range types {
+		if discriminated.Name == name {
+			return discriminated, true
+		}
+	}
+
+	for _, discriminated := range types {
+		if slices.ContainsFunc(discriminated.Subtypes, func(subtype Subtype) bool { return subtype.Name == name }) {
+			return discriminated, true
+		}
+	}
+
+	return DiscriminatedType{}, false
+}
+
+// CheckDiscriminators checks that the discriminator of every discriminated type is a required
+// string property, and that subtypes resolve to distinct discriminator values.
+//
+// Issues are sorted by key.
+func (s *Spec) CheckDiscriminators() []DiscriminatorIssue {
+	var issues []DiscriminatorIssue
+
+	for _, discriminated := range s.DiscriminatedTypes() {
+		key := "#" + slashpath.Join("/definitions", jsonpointer.Escape(discriminated.Name))
+		report := func(kind DiscriminatorIssueKind, message string) {
+			issues = append(issues, DiscriminatorIssue{Key: key, Kind: kind, Message: message})
+		}
+
+		base := s.spec.Definitions[discriminated.Name]
+		property, required, found := discriminatorProperty(&base, discriminated.Discriminator)
+		property = s.resolveDefinitionRef(property)
+		switch {
+		case !found:
+			report(DiscriminatorMissingProperty,
+				fmt.Sprintf("discriminator %q is not a property of %q", discriminated.Discriminator, discriminated.Name))
+		case !property.Type.Contains("string") || len(property.Type) != 1:
+			report(DiscriminatorNotString, fmt.Sprintf("discriminator %q must be a string", discriminated.Discriminator))
+		}
+
+		if found && !required {
+			report(DiscriminatorNotRequired, fmt.Sprintf("discriminator %q must be required", discriminated.Discriminator))
+		}
+
+		values := map[string]string{discriminatorValue(discriminated.Name, base): discriminated.Name}
+		for _, subtype := range discriminated.Subtypes {
+			if other, duplicate := values[subtype.Value]; duplicate {
+				report(DiscriminatorDuplicateValue,
+					fmt.Sprintf("%q and %q have the same discriminator value %q", other, subtype.Name, subtype.Value))
+
+				continue
+			}
+			values[subtype.Value] = subtype.Name
+		}
+	}
+
+	return issues
+}
+
+// polymorphicDefinitions yields the keys of all the definitions which take part in a polymorphic hierarchy,
+// i.e. discriminated types and their subtypes.
+func (s *Spec) polymorphicDefinitions() map[string]struct{} {
+	polymorphic := make(map[string]struct{}, allocSmallMap)
+	for _, discriminated := range s.DiscriminatedTypes() {
+		polymorphic[slashpath.Join(definitionsPath, jsonpointer.Escape(discriminated.Name))] = struct{}{}
+		for _, subtype := range discriminated.Subtypes {
+			polymorphic[slashpath.Join(definitionsPath, jsonpointer.Escape(subtype.Name))] = struct{}{}
+		}
+	}
+
+	return polymorphic
+}
+
+// allOfChildren maps definitions to the definitions which refer to them in an allOf.
+func (s *Spec) allOfChildren() map[string][]string {
+	children := make(map[string][]string, len(s.allOfs))
+	for _, schRef := range s.allOfs {
+		if !schRef.TopLevel || schRef.Schema == nil {
+			continue
+		}
+
+		for _, member := range schRef.Schema.AllOf {
+			parent, ok := definitionName(member.Ref.String())
+			if ok {
+				children[parent] = append(children[parent], schRef.Name)
+			}
+		}
+	}
+
+	for parent := range children {
+		slices.Sort(children[parent])
+	}
+
+	return children
+}
+
+// discriminatorProperty looks up the discriminator in the properties of the base type, including its inline allOf members.
+func discriminatorProperty(base *spec.Schema, discriminator string) (spec.Schema, bool, bool) {
+	var (
+		property spec.Schema
+		found    bool
+		required bool
+	)
+
+	members := make([]*spec.Schema, 0, len(base.AllOf)+1)
+	members = append(members, base)
+	for i := range base.AllOf {
+		members = append(members, &base.AllOf[i])
+	}
+
+	for _, member := range members {
+		if candidate, ok := member.Properties[discriminator]; ok && !found {
+			property, found = candidate, true
+		}
+		required = required || slices.Contains(member.Required, discriminator)
+	}
+
+	return property, required, found
+}
+
+// resolveDefinitionRef follows the local $ref of a schema to definitions.
+//
+// The schema is returned as is when some $ref cannot be resolved.
+func (s *Spec) resolveDefinitionRef(schema spec.Schema) spec.Schema {
+	seen := make(map[string]struct{})
+	for schema.Ref.String() != "" {
+		key := schema.Ref.String()
+		if _, cyclic := seen[key]; cyclic {
+			return schema
+		}
+		seen[key] = struct{}{}
+
+		name, ok := definitionName(key)
+		if !ok {
+			return schema
+		}
+
+		target, ok := s.spec.Definitions[name]
+		if !ok {
+			return schema
+		}
+		schema = target
+	}
+
+	return schema
+}
+
+func discriminatorValue(name string, schema spec.Schema) string {
+	if value, ok := schema.Extensions.GetString(discriminatorValueExtension); ok && value != "" {
+		return value
+	}
+
+	return name
+}
+
+// definitionName yields the name of the definition a local $ref points to.
+func definitionName(ref string) (string, bool) {
+	if len(pointerParts(ref)) != 2 { //nolint:mnd // definitions/name
+		return "", false
+	}
+
+	return enclosingDefinitionName(ref)
+}
+
+// enclosingDefinitionName yields the name of the definition a local $ref points to, or points into.
+func enclosingDefinitionName(ref string) (string, bool) {
+	parts := pointerParts(ref)
+	if !strings.HasPrefix(ref, "#/") || len(parts) < 2 || parts[0] != "definitions" { //nolint:mnd // definitions/name
+		return "", false
+	}
+
+	return parts[1], true
+}
@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/analysis/internal/antest"
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestAnalyzer_DiscriminatedTypes(t *testing.T) {
+	t.Parallel()
+
+	doc := antest.LoadOrFail(t, filepath.Join("fixtures", "discriminator.yml"))
+	an := New(doc)
+
+	types := an.DiscriminatedTypes()
+	require.Len(t, types, 4)
+
+	assert.Equal(t, DiscriminatedType{
+		Name:          "Pet",
+		Discriminator: "petType",
+		Subtypes: []Subtype{
+			{Name: "Cat", Parent: "Pet", Value: "Cat"},
+			{Name: "Dog", Parent: "Pet", Value: "dog"},
+			{Name: "Puppy", Parent: "Dog", Value: "dog"},
+			{Name: "Unrelated", Parent: "Cat", Value: "Unrelated"},
+		},
+	}, types[0])
+	assert.Equal(t, "Shape", types[1].Name)
+	assert.Equal(t, []Subtype{{Name: "Unrelated", Parent: "Shape", Value: "Unrelated"}}, types[1].Subtypes)
+	assert.Equal(t, "Vehicle", types[2].Name)
+	assert.Equal(t, "Widget", types[3].Name)
+
+	pet, ok := an.DiscriminatedTypeFor("Pet")
+	require.True(t, ok)
+	subtype, ok := pet.SubtypeFor("dog")
+	require.True(t, ok)
+	assert.Equal(t, "Dog", subtype.Name)
+	_, ok = pet.SubtypeFor("Dog")
+	assert.False(t, ok)
+
+	t.Run("should find the hierarchy of a subtype", func(t *testing.T) {
+		for _, name := range []string{"Cat", "Puppy", "Unrelated"} {
+			discriminated, ok := an.DiscriminatedTypeFor(name)
+			require.Truef(t, ok, "expected a discriminated type for %s", name)
+			assert.Equal(t, "Pet", discriminated.Name)
+		}
+
+		_, ok := an.DiscriminatedTypeFor("Shape")
+		require.True(t, ok)
+		_, ok = an.DiscriminatedTypeFor("Car")
+		require.True(t, ok)
+		_, ok = an.DiscriminatedTypeFor("missing")
+		assert.False(t, ok)
+	})
+}
+
+func TestAnalyzer_CheckDiscriminators(t *testing.T) {
+	t.Parallel()
+
+	doc := antest.LoadOrFail(t, filepath.Join("fixtures", "discriminator.yml"))
+	issues := New(doc).CheckDiscriminators()
+
+	type found struct {
+		key  string
+		kind DiscriminatorIssueKind
+	}
+	actual := make([]found, 0, len(issues))
+	for _, issue := range issues {
+		assert.NotEmpty(t, issue.Message)
+		actual = append(actual, found{key: issue.Key, kind: issue.Kind})
+	}
+
+	assert.Equal(t, []found{
+		{key: "#/definitions/Pet", kind: DiscriminatorDuplicateValue},
+		{key: "#/definitions/Shape", kind: DiscriminatorMissingProperty},
+		{key: "#/definitions/Vehicle", kind: DiscriminatorNotString},
+		{key: "#/definitions/Vehicle", kind: DiscriminatorNotRequired},
+		// Widget has a discriminator property with a $ref to a string enum: no issue
+	}, actual)
+}
@@ -0,0 +1,306 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/go-openapi/jsonpointer"
+	"github.com/go-openapi/spec"
+	"github.com/go-openapi/strfmt"
+)
+
+// EnumIssueKind qualifies an issue found with an enum.
+type EnumIssueKind string
+
+const (
+	// EnumTypeMismatch reports an enum value which does not match the declared type.
+	EnumTypeMismatch EnumIssueKind = "type-mismatch"
+
+	// EnumFormatMismatch reports an enum value which does not match the declared format.
+	EnumFormatMismatch EnumIssueKind = "format-mismatch"
+
+	// EnumDuplicate reports an enum value declared more than once.
+	EnumDuplicate EnumIssueKind = "duplicate"
+
+	// EnumDefaultNotInEnum reports a default value which is not one of the enum values.
+	EnumDefaultNotInEnum EnumIssueKind = "default-not-in-enum"
+
+	// EnumExampleNotInEnum reports an example which is not one of the enum values.
+	EnumExampleNotInEnum EnumIssueKind = "example-not-in-enum"
+)
+
+// EnumIssue reports an issue with the enum located at Key.
+type EnumIssue struct {
+	// Key is the JSON pointer to the construct declaring the enum, as in [Spec.AllEnums].
+	Key     string
+	Kind    EnumIssueKind
+	Value   any
+	Message string
+}
+
+// EnumGroup gathers the locations of structurally identical enums.
+type EnumGroup struct {
+	Type   string
+	Format string
+
+	// Values of the enum, as declared at the first location.
+	Values []any
+
+	// Keys are the JSON pointers to the constructs declaring the enum, in lexicographic order.
+	Keys []string
+}
+
+// enumOwner captures the properties of the construct declaring an enum.
+type enumOwner struct {
+	types    []string
+	format   string
+	nullable bool
+	defaults any
+	example  any
+}
+
+// CheckEnums checks all the enums found in the spec against the type and format of their
+// declaring construct.
+//
+// Duplicate values are reported, as well as default values and examples which are not part of the enum.
+//
+// Issues are sorted by key.
+func (s *Spec) CheckEnums() []EnumIssue {
+	var issues []EnumIssue
+	for _, key := range sortedKeys(s.enums.allEnums) {
+		owner, ok := s.enumOwner(key)
+		if !ok {
+			continue
+		}
+
+		issues = append(issues, checkEnum(key, s.enums.allEnums[key], owner)...)
+	}
+
+	return issues
+}
+
+// EnumGroups gathers enums which are declared with the same type, format and set of values
+// at different locations, and could be shared.
+//
+// Groups are sorted by their first key.
+func (s *Spec) EnumGroups() []EnumGroup {
+	groups := make(map[string]*EnumGroup, allocSmallMap)
+	for _, key := range sortedKeys(s.enums.allEnums) {
+		owner, ok := s.enumOwner(key)
+		if !ok {
+			continue
+		}
+
+		enum := s.enums.allEnums[key]
+		values := make([]string, 0, len(enum))
+		for _, value := range enum {
+			values = append(values, canonicalValue(value))
+		}
+		slices.Sort(values)
+		values = slices.Compact(values)
+
+		typ := strings.Join(owner.types, ",")
+		signature := strings.Join(append([]string{typ, owner.format}, values...), "\x00")
+		group, ok := groups[signature]
+		if !ok {
+			group = &EnumGroup{Type: typ, Format: owner.format, Values: enum}
+			groups[signature] = group
+		}
+		group.Keys = append(group.Keys, key)
+	}
+
+	result := make([]EnumGroup, 0, len(groups))
+	for _, group := range groups {
+		if len(group.Keys) > 1 {
+			result = append(result, *group)
+		}
+	}
+	slices.SortFunc(result, func(a, b EnumGroup) int { return strings.Compare(a.Keys[0], b.Keys[0]) })
+
+	return result
+}
+
+func checkEnum(key string, enum []any, owner enumOwner) []EnumIssue {
+	var issues []EnumIssue
+	report := func(kind EnumIssueKind, value any, message string) {
+		issues = append(issues, EnumIssue{Key: key, Kind: kind, Value: value, Message: message})
+	}
+
+	known := make(map[string]struct{}, len(enum))
+	for _, value := range enum {
+		canonical := canonicalValue(value)
+		if _, duplicate := known[canonical]; duplicate {
+			report(EnumDuplicate, value, fmt.Sprintf("duplicate enum value %s", canonical))
+		}
+		known[canonical] = struct{}{}
+
+		if !owner.matchesType(value) {
+			report(EnumTypeMismatch, value,
+				fmt.Sprintf("enum value %s does not match type %q", canonical, strings.Join(owner.types, ",")))
+
+			continue
+		}
+
+		if !owner.matchesFormat(value) {
+			report(EnumFormatMismatch, value, fmt.Sprintf("enum value %s does not match format %q", canonical, owner.format))
+		}
+	}
+
+	if owner.defaults != nil {
+		if _, ok := known[canonicalValue(owner.defaults)]; !ok {
+			report(EnumDefaultNotInEnum, owner.defaults,
+				fmt.Sprintf("default value %s is not in enum", canonicalValue(owner.defaults)))
+		}
+	}
+
+	if owner.example != nil {
+		if _, ok := known[canonicalValue(owner.example)]; !ok {
+			report(EnumExampleNotInEnum, owner.example,
+				fmt.Sprintf("example %s is not in enum", canonicalValue(owner.example)))
+		}
+	}
+
+	return issues
+}
+
+// enumOwner resolves the schema, parameter, header or items declaring the enum at key.
+func (s *Spec) enumOwner(key string) (enumOwner, bool) {
+	if schRef, ok := s.allSchemas[key]; ok && schRef.Schema != nil {
+		schema := schRef.Schema
+		nullable, _ := schema.Extensions.GetBool("x-nullable")
+		isNullable, _ := schema.Extensions.GetBool("x-isnullable")
+
+		return enumOwner{
+			types:    schema.Type,
+			format:   schema.Format,
+			nullable: schema.Nullable || nullable || isNullable,
+			defaults: schema.Default,
+			example:  schema.Example,
+		}, true
+	}
+
+	ptr, err := jsonpointer.New(strings.TrimPrefix(key, "#"))
+	if err != nil {
+		return enumOwner{}, false
+	}
+
+	obj, _, err := ptr.Get(s.spec)
+	if err != nil {
+		return enumOwner{}, false
+	}
+
+	var simple *spec.SimpleSchema
+	switch construct := obj.(type) {
+	case spec.Parameter:
+		simple = &construct.SimpleSchema
+	case *spec.Parameter:
+		simple = &construct.SimpleSchema
+	case spec.Header:
+		simple = &construct.SimpleSchema
+	case *spec.Header:
+		simple = &construct.SimpleSchema
+	case spec.Items:
+		simple = &construct.SimpleSchema
+	case *spec.Items:
+		simple = &construct.SimpleSchema
+	default:
+		return enumOwner{}, false
+	}
+
+	owner := enumOwner{format: simple.Format, nullable: simple.Nullable, defaults: simple.Default, example: simple.Example}
+	if simple.Type != "" {
+		owner.types = []string{simple.Type}
+	}
+
+	return owner, true
+}
+
+func (o enumOwner) matchesType(value any) bool {
+	if len(o.types) == 0 {
+		return true
+	}
+
+	if value == nil {
+		return o.nullable || slices.Contains(o.types, "null")
+	}
+
+	return slices.ContainsFunc(o.types, func(typ string) bool {
+		return matchesType(typ, value)
+	})
+}
+
+func matchesType(typ string, value any) bool {
+	switch typ {
+	case "string":
+		_, ok := value.(string)
+
+		return ok
+	case "integer":
+		number, ok := asFloat(value)
+
+		return ok && number == math.Trunc(number)
+	case "number":
+		_, ok := asFloat(value)
+
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+
+		return ok
+	case "array":
+		return reflect.ValueOf(value).Kind() == reflect.Slice
+	case "object":
+		return reflect.ValueOf(value).Kind() == reflect.Map
+	default:
+		return true
+	}
+}
+
+func (o enumOwner) matchesFormat(value any) bool {
+	switch o.format {
+	case "":
+		return true
+	case "int32":
+		number, ok := asFloat(value)
+
+		return !ok || (number >= math.MinInt32 && number <= math.MaxInt32)
+	}
+
+	str, ok := value.(string)
+	if !ok || !strfmt.Default.ContainsName(o.format) {
+		return true
+	}
+
+	return strfmt.Default.Validates(o.format, str)
+}
+
+func asFloat(value any) (float64, bool) {
+	v := reflect.ValueOf(value)
+	switch {
+	case v.CanInt():
+		return float64(v.Int()), true
+	case v.CanUint():
+		return float64(v.Uint()), true
+	case v.CanFloat():
+		return v.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// canonicalValue yields a JSON representation of an enum value, so values may be compared.
+func canonicalValue(value any) string {
+	buf, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%#v", value)
+	}
+
+	return string(buf)
+}
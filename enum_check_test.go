@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/analysis/internal/antest"
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestAnalyzer_CheckEnums(t *testing.T) {
+	t.Parallel()
+
+	doc := antest.LoadOrFail(t, filepath.Join("fixtures", "enum-checks.yml"))
+	issues := New(doc).CheckEnums()
+
+	type found struct {
+		key   string
+		kind  EnumIssueKind
+		value any
+	}
+	actual := make([]found, 0, len(issues))
+	for _, issue := range issues {
+		assert.NotEmpty(t, issue.Message)
+		actual = append(actual, found{key: issue.Key, kind: issue.Kind, value: issue.Value})
+	}
+
+	assert.Equal(t, []found{
+		{key: "#/definitions/pet/properties/kind", kind: EnumTypeMismatch, value: true},
+		{key: "#/definitions/pet/properties/status", kind: EnumExampleNotInEnum, value: "unknown"},
+		{key: "#/parameters/status", kind: EnumDefaultNotInEnum, value: "archived"},
+		{key: "#/paths/~1pets/get/parameters/1", kind: EnumDuplicate, value: float64(20)},
+		{key: "#/paths/~1pets/get/parameters/1", kind: EnumTypeMismatch, value: 2.5},
+		{key: "#/paths/~1pets/get/parameters/1", kind: EnumTypeMismatch, value: "50"},
+		{key: "#/paths/~1pets/get/parameters/1", kind: EnumFormatMismatch, value: float64(4294967296)},
+		{key: "#/paths/~1pets/get/parameters/2", kind: EnumFormatMismatch, value: "yesterday"},
+	}, actual)
+}
+
+func TestAnalyzer_EnumGroups(t *testing.T) {
+	t.Parallel()
+
+	doc := antest.LoadOrFail(t, filepath.Join("fixtures", "enum-checks.yml"))
+	groups := New(doc).EnumGroups()
+
+	require.Len(t, groups, 2)
+	assert.Equal(t, EnumGroup{
+		Type:   "string",
+		Values: []any{"small", "large"},
+		Keys:   []string{"#/definitions/pet/properties/size", "#/definitions/size"},
+	}, groups[0])
+
+	assert.Equal(t, "string", groups[1].Type)
+	assert.Equal(t, []string{
+		"#/parameters/status",
+		"#/paths/~1pets/get/responses/200/headers/X-Status",
+	}, groups[1].Keys)
+}
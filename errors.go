@@ -13,6 +13,11 @@ type analysisError string
 const (
 	ErrAnalysis analysisError = "analysis error"
 	ErrNoSchema analysisError = "no schema to analyze"
+
+	ErrImmutableSpec analysisError = "cannot update an immutable analyzed spec"
+	ErrNoSpec        analysisError = "no analyzed spec to flatten"
+
+	ErrParamDiagnostic analysisError = "parameter diagnostic"
 )
 
 func (e analysisError) Error() string {
@@ -26,6 +31,42 @@ func ErrAtKey(key string, err error) error {
 	)
 }
 
+func ErrPositions(file string, err error) error {
+	return errors.Join(
+		fmt.Errorf("could not map the positions in %q: %w", file, err),
+		ErrAnalysis,
+	)
+}
+
+func ErrDuplicateParam(key string) error {
+	return paramDiagnostic(IssueParamDuplicate, fmt.Sprintf("duplicate parameter %q", key))
+}
+
+func ErrIncompatibleParamOverride(key, pathType, opType string) error {
+	return paramDiagnostic(IssueParamIncompatibleOverride,
+		fmt.Sprintf("parameter %q of type %q overrides a path parameter of type %q", key, opType, pathType))
+}
+
+func ErrMultipleBodyParams(names []string) error {
+	return paramDiagnostic(IssueParamMultipleBody, fmt.Sprintf("multiple body parameters: %v", names))
+}
+
+func ErrBodyWithFormData(body, formData string) error {
+	return paramDiagnostic(IssueParamBodyWithFormData,
+		fmt.Sprintf("body parameter %q cannot be combined with formData parameter %q", body, formData))
+}
+
+func paramDiagnostic(code IssueCode, message string) error {
+	return codedError{
+		error: errors.Join(
+			fmt.Errorf("%s: %w", message, ErrParamDiagnostic),
+			ErrAnalysis,
+		),
+		code:    code,
+		message: message,
+	}
+}
+
 func ErrInvalidRef(key string) error {
 	return fmt.Errorf("invalid reference: %q: %w", key, ErrAnalysis)
 }
@@ -34,6 +75,14 @@ func ErrInvalidParameterRef(key string) error {
 	return fmt.Errorf("resolved reference is not a parameter: %q: %w", key, ErrAnalysis)
 }
 
+func ErrInvalidResponseRef(key string) error {
+	return fmt.Errorf("resolved reference is not a response: %q: %w", key, ErrAnalysis)
+}
+
+func ErrInvalidItemsRef(key string) error {
+	return fmt.Errorf("resolved reference is not a simple item: %q: %w", key, ErrAnalysis)
+}
+
 func ErrResolveSchema(err error) error {
 	return errors.Join(
 		fmt.Errorf("could not resolve schema: %w", err),
@@ -4,7 +4,7 @@
 package analysis
 
 import (
-	"log"
+	"maps"
 	"path"
 	"slices"
 	"sort"
@@ -35,18 +35,35 @@ type newRef struct {
 // context stores intermediary results from flatten.
 type context struct {
 	newRefs  map[string]*newRef
-	warnings []string
+	warnings []Issue
 	resolved map[string]string
+	changes  []FlattenChange
 }
 
 func newContext() *context {
 	return &context{
 		newRefs:  make(map[string]*newRef, allocMediumMap),
-		warnings: make([]string, 0),
+		warnings: make([]Issue, 0),
 		resolved: make(map[string]string, allocMediumMap),
 	}
 }
 
+// warnRefs records the warnings issued when resolving the $ref located at key.
+func (c *context) warnRefs(key string, warnings []string) {
+	if c == nil {
+		return
+	}
+
+	for _, msg := range warnings {
+		c.warnings = append(c.warnings, Issue{
+			Severity: SeverityWarning,
+			Code:     IssueFlattenRefAsSchema,
+			Pointer:  key,
+			Message:  msg,
+		})
+	}
+}
+
 // Flatten an analyzed spec and produce a self-contained spec bundle.
 //
 // There is a minimal and a full flattening mode.
@@ -86,8 +103,12 @@ func newContext() *context {
 //
 //   - Minimal: stops flattening after minimal $ref processing, leaving schema constructs untouched
 //   - Expand: expand all $ref's in the document (inoperant if Minimal set to true)
-//   - Verbose: croaks about name conflicts detected
+//   - Verbose: logs warnings about name conflicts detected and other possibly unwanted constructs
 //   - RemoveUnused: removes unused parameters, responses and definitions after expansion/flattening
+//   - SimplifyAllOf: merges inline allOf members into their parent schema whenever it is safe to do so,
+//     and lifts allOf compositions wrapping a single $ref
+//   - DeduplicateDefinitions: collapses structurally identical definitions into a single one
+//   - NamingStrategy: customizes the names of the definitions created for inline schemas
 //
 // NOTE: expansion removes all $ref save circular $ref, which remain in place
 //
@@ -95,20 +116,49 @@ func newContext() *context {
 //
 //   - PropagateNameExtensions: ensure that created entries properly follow naming rules when their parent have set a
 //     x-go-name extension
-//   - LiftAllOfs:
-//   - limit the flattening of allOf members when simple objects
-//   - merge allOf with validation only
-//   - merge allOf with extensions only
 //   - ...
+//
+// Use [FlattenWithIssues] to collect warnings as [Issue]s rather than logging them,
+// or [FlattenWithReport] to find out about all the changes performed on the spec.
 func Flatten(opts FlattenOpts) error {
-	debugLog("FlattenOpts: %#v", opts)
+	report, err := FlattenWithReport(opts)
+	if err != nil {
+		return err
+	}
+
+	if opts.Verbose {
+		report.log()
+	}
+
+	return nil
+}
+
+// FlattenWithIssues flattens an analyzed spec like [Flatten], and returns the warnings about valid,
+// but possibly unwanted constructs resulting from flattening the spec.
+//
+// Issues are sorted by pointer, then by code. Nothing is logged, regardless of the Verbose option.
+func FlattenWithIssues(opts FlattenOpts) ([]Issue, error) {
+	report, err := FlattenWithReport(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return report.Issues, nil
+}
+
+func flatten(opts *FlattenOpts) error {
+	debugLog("FlattenOpts: %#v", *opts)
+
+	if opts.Spec != nil && opts.Spec.readOnly {
+		return ErrImmutableSpec
+	}
 
 	opts.flattenContext = newContext()
 
 	// 1. Recursively expand responses, parameters, path items and items in simple schemas.
 	//
 	// This simplifies the spec and leaves only the $ref's in schema objects.
-	if err := expand(&opts); err != nil {
+	if err := expand(opts); err != nil {
 		return err
 	}
 
@@ -116,7 +166,7 @@ func Flatten(opts FlattenOpts) error {
 	// so we can recognize them as proper definitions
 	//
 	// In particular, this works around issue go-openapi/spec#76: leading absolute file in $ref is stripped
-	if err := normalizeRef(&opts); err != nil {
+	if err := normalizeRef(opts); err != nil {
 		return err
 	}
 
@@ -124,52 +174,64 @@ func Flatten(opts FlattenOpts) error {
 	//
 	// Operation parameters (i.e. under paths) remain.
 	if opts.RemoveUnused {
-		removeUnusedShared(&opts)
+		removeUnusedShared(opts)
 	}
 
 	// 4. Import all remote references.
-	if err := importReferences(&opts); err != nil {
+	if err := importReferences(opts); err != nil {
 		return err
 	}
 
-	// 5. full flattening: rewrite inline schemas (schemas that aren't simple types or arrays or maps)
+	// 5. Optionally simplify allOf compositions, before inline schemas are named.
+	if opts.SimplifyAllOf {
+		if err := simplifyAllOfs(opts); err != nil {
+			return err
+		}
+	}
+
+	// 6. full flattening: rewrite inline schemas (schemas that aren't simple types or arrays or maps)
 	if !opts.Minimal && !opts.Expand {
-		if err := nameInlinedSchemas(&opts); err != nil {
+		if err := nameInlinedSchemas(opts); err != nil {
 			return err
 		}
 	}
 
-	// 6. Rewrite JSON pointers other than $ref to named definitions
+	// 7. Rewrite JSON pointers other than $ref to named definitions
 	// and attempt to resolve conflicting names whenever possible.
-	if err := stripPointersAndOAIGen(&opts); err != nil {
+	if err := stripPointersAndOAIGen(opts); err != nil {
 		return err
 	}
 
-	// 7. Strip the spec from unused definitions
-	if opts.RemoveUnused {
-		removeUnused(&opts)
+	// 8. Optionally collapse identical definitions.
+	if opts.DeduplicateDefinitions {
+		if err := deduplicateDefinitions(opts); err != nil {
+			return err
+		}
 	}
 
-	// 8. Issue warning notifications, if any
-	opts.croak()
-
-	// TODO: simplify known schema patterns to flat objects with properties
-	// examples:
-	//  - lift simple allOf object,
-	//  - empty allOf with validation only or extensions only
-	//  - rework allOf arrays
-	//  - rework allOf additionalProperties
+	// 9. Strip the spec from unused definitions
+	if opts.RemoveUnused {
+		removeUnused(opts)
+	}
 
 	return nil
 }
 
 func expand(opts *FlattenOpts) error {
+	expandable := maps.Clone(expandableRefs(opts))
 	if err := spec.ExpandSpec(opts.Swagger(), opts.ExpandOpts(!opts.Expand)); err != nil {
 		return err
 	}
 
 	opts.Spec.reload() // re-analyze
 
+	for _, key := range sortedKeys(expandable) {
+		from := expandable[key]
+		if ref, ok := opts.Spec.references.allRefs[key]; !ok || ref.String() != from.String() {
+			opts.flattenContext.record(FlattenExpanded, key, from.String(), "")
+		}
+	}
+
 	return nil
 }
 
@@ -188,10 +250,11 @@ func normalizeRef(opts *FlattenOpts) error {
 		debugLog("stripping absolute path for: %s", w.String())
 
 		// strip the base path from definition
-		if err := replace.UpdateRef(opts.Swagger(), k,
-			spec.MustCreateRef(path.Join(definitionsPath, path.Base(w.String())))); err != nil {
+		normalized := path.Join(definitionsPath, path.Base(w.String()))
+		if err := replace.UpdateRef(opts.Swagger(), k, spec.MustCreateRef(normalized)); err != nil {
 			return err
 		}
+		opts.flattenContext.record(FlattenRewritten, k, w.String(), normalized)
 	}
 
 	if altered {
@@ -202,6 +265,9 @@ func normalizeRef(opts *FlattenOpts) error {
 }
 
 func removeUnusedShared(opts *FlattenOpts) {
+	recordRemoved(opts.flattenContext, "#/parameters", opts.Swagger().Parameters)
+	recordRemoved(opts.flattenContext, "#/responses", opts.Swagger().Responses)
+
 	opts.Swagger().Parameters = nil
 	opts.Swagger().Responses = nil
 
@@ -276,12 +342,10 @@ func removeUnusedSinglePass(opts *FlattenOpts) (hasRemoved bool) {
 		delete(expected, k)
 	}
 
-	for k := range expected {
+	for _, k := range sortedKeys(expected) {
 		hasRemoved = true
 		debugLog("removing unused definition %s", path.Base(k))
-		if opts.Verbose {
-			log.Printf("info: removing unused definition: %s", path.Base(k))
-		}
+		opts.flattenContext.record(FlattenRemoved, k, "", "")
 		delete(opts.Swagger().Definitions, path.Base(k))
 	}
 
@@ -299,6 +363,7 @@ func importKnownRef(entry sortref.RefRevIdx, refStr, newName string, opts *Flatt
 		if err := replace.UpdateRef(opts.Swagger(), key, spec.MustCreateRef(path.Join(definitionsPath, newName))); err != nil {
 			return err
 		}
+		opts.flattenContext.record(FlattenImported, key, refStr, path.Join(definitionsPath, newName))
 	}
 
 	return nil
@@ -345,6 +410,7 @@ func importNewRef(entry sortref.RefRevIdx, refStr string, opts *FlattenOpts) err
 			spec.MustCreateRef(path.Join(definitionsPath, newName))); err != nil {
 			return err
 		}
+		opts.flattenContext.record(FlattenImported, key, entry.Ref.String(), path.Join(definitionsPath, newName))
 
 		// keep track of created refs
 		resolved := false
@@ -565,6 +631,7 @@ func stripOAIGenForRef(opts *FlattenOpts, k string, r *newRef) (bool, error) {
 	if err := replace.UpdateRefWithSchema(opts.Swagger(), pr[0], r.schema); err != nil {
 		return false, err
 	}
+	opts.flattenContext.record(FlattenMerged, r.path, "", pr[0])
 
 	if pa, ok := opts.flattenContext.newRefs[pr[0]]; ok && pa.isOAIGen {
 		// update parent in ref index entry
@@ -588,6 +655,7 @@ func stripOAIGenForRef(opts *FlattenOpts, k string, r *newRef) (bool, error) {
 			if err := replace.UpdateRef(opts.Swagger(), p, replacingRef); err != nil {
 				return false, err
 			}
+			opts.flattenContext.record(FlattenRewritten, p, r.path, replacingRef.String())
 
 			if pa, ok := opts.flattenContext.newRefs[p]; ok && pa.isOAIGen {
 				// update parent in ref index
@@ -691,9 +759,7 @@ func namePointers(opts *FlattenOpts) error {
 
 		replacingRef := result.Ref
 		sch := result.Schema
-		if opts.flattenContext != nil {
-			opts.flattenContext.warnings = append(opts.flattenContext.warnings, result.Warnings...)
-		}
+		opts.flattenContext.warnRefs(k, result.Warnings)
 
 		debugLog("planning pointer to replace at %s: %s, resolved to: %s", k, ref.String(), replacingRef.String())
 		refsToReplace[k] = SchemaRef{
@@ -720,9 +786,7 @@ func namePointers(opts *FlattenOpts) error {
 			return ErrAtKey(key, erd)
 		}
 
-		if opts.flattenContext != nil {
-			opts.flattenContext.warnings = append(opts.flattenContext.warnings, result.Warnings...)
-		}
+		opts.flattenContext.warnRefs(key, result.Warnings)
 
 		v.Ref = result.Ref
 		v.Schema = result.Schema
@@ -733,9 +797,14 @@ func namePointers(opts *FlattenOpts) error {
 			debugLog("replace pointer %s by canonical definition: %s", key, v.Ref.String())
 
 			// if the schema is a $ref to a top level definition, just rewrite the pointer to this $ref
+			from := opts.Spec.references.allRefs[key]
 			if err := replace.UpdateRef(opts.Swagger(), key, v.Ref); err != nil {
 				return err
 			}
+			if from.String() != v.Ref.String() {
+				opts.flattenContext.record(FlattenRewritten, key, from.String(), v.Ref.String())
+			}
+			opts.Spec.Reanalyze(key)
 
 			continue
 		}
@@ -769,16 +838,13 @@ func flattenAnonPointer(key string, v SchemaRef, refsToReplace map[string]Schema
 
 	debugLog("looking for callers")
 
-	an := New(opts.Swagger())
-	for k, w := range an.references.allRefs {
+	for k, w := range opts.Spec.references.allRefs {
 		r, err := replace.DeepestRef(opts.Swagger(), opts.ExpandOpts(false), w)
 		if err != nil {
 			return ErrAtKey(key, err)
 		}
 
-		if opts.flattenContext != nil {
-			opts.flattenContext.warnings = append(opts.flattenContext.warnings, r.Warnings...)
-		}
+		opts.flattenContext.warnRefs(k, r.Warnings)
 
 		if r.Ref.String() == v.Ref.String() {
 			callers = append(callers, k)
@@ -825,9 +891,12 @@ func flattenAnonPointer(key string, v SchemaRef, refsToReplace map[string]Schema
 	// everything that is a simple schema and not factorizable is expanded
 	debugLog("expand JSON pointer for key=%s", key)
 
+	from := opts.Spec.references.allRefs[key]
 	if err := replace.UpdateRefWithSchema(opts.Swagger(), key, v.Schema); err != nil {
 		return err
 	}
+	opts.flattenContext.record(FlattenExpanded, key, from.String(), "")
+	opts.Spec.Reanalyze(key)
 	// NOTE: there is no other caller to update
 
 	return nil
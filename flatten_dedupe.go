@@ -0,0 +1,201 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"cmp"
+	"encoding/json"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/go-openapi/analysis/internal/flatten/replace"
+	"github.com/go-openapi/jsonpointer"
+	"github.com/go-openapi/spec"
+	"github.com/go-openapi/swag/jsonutils"
+)
+
+const genLocationExtension = "x-go-gen-location"
+
+// deduplicateDefinitions collapses structurally identical definitions into a single canonical one,
+// and rewrites all the $ref pointing to the duplicates.
+//
+// Since rewriting $ref may in turn make other definitions identical, this is repeated until no
+// duplicate remains.
+func deduplicateDefinitions(opts *FlattenOpts) error {
+	debugLog("deduplicateDefinitions")
+
+	for {
+		duplicates := duplicateDefinitions(opts)
+		if len(duplicates) == 0 {
+			return nil
+		}
+
+		if err := collapseDefinitions(opts, duplicates); err != nil {
+			return err
+		}
+	}
+}
+
+// duplicateDefinitions maps the names of duplicate definitions to the name of their canonical definition.
+//
+// Definitions which take part in a polymorphic hierarchy are never considered duplicates,
+// since their name matters.
+func duplicateDefinitions(opts *FlattenOpts) map[string]string {
+	polymorphic := opts.Spec.polymorphicDefinitions()
+	definitions := opts.Swagger().Definitions
+
+	groups := make(map[string][]string, len(definitions))
+	for _, name := range sortedKeys(definitions) {
+		if _, isPolymorphic := polymorphic[path.Join(definitionsPath, jsonpointer.Escape(name))]; isPolymorphic {
+			continue
+		}
+
+		sch := definitions[name]
+		signature, ok := definitionSignature(&sch, opts.DeduplicateIgnoreDocs)
+		if !ok {
+			continue
+		}
+		groups[signature] = append(groups[signature], name)
+	}
+
+	duplicates := make(map[string]string, allocSmallMap)
+	for _, names := range groups {
+		if len(names) < 2 { //nolint:mnd // no duplicate
+			continue
+		}
+
+		slices.SortFunc(names, func(a, b string) int {
+			return compareCanonicalNames(definitions, a, b)
+		})
+		for _, duplicate := range names[1:] {
+			duplicates[duplicate] = names[0]
+		}
+	}
+
+	return duplicates
+}
+
+// compareCanonicalNames ranks the names of identical definitions: definitions from the original spec
+// are preferred over those created by flatten, then names without a generated "OAIGen" suffix,
+// then shorter names.
+func compareCanonicalNames(definitions spec.Definitions, a, b string) int {
+	_, aIsGenerated := definitions[a].Extensions[genLocationExtension]
+	_, bIsGenerated := definitions[b].Extensions[genLocationExtension]
+
+	return cmp.Or(
+		compareBool(aIsGenerated, bIsGenerated),
+		compareBool(strings.Contains(a, "OAIGen"), strings.Contains(b, "OAIGen")),
+		cmp.Compare(len(a), len(b)),
+		strings.Compare(a, b),
+	)
+}
+
+func compareBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	default:
+		return -1
+	}
+}
+
+// definitionSignature yields the normalized JSON of a definition, which is the same for structurally
+// identical definitions.
+//
+// The x-go-gen-location extension added by flatten is ignored, as well as titles and descriptions
+// when ignoreDocs is enabled.
+func definitionSignature(sch *spec.Schema, ignoreDocs bool) (string, bool) {
+	var fields map[string]any
+	if err := jsonutils.FromDynamicJSON(sch, &fields); err != nil {
+		return "", false
+	}
+	delete(fields, genLocationExtension)
+
+	if ignoreDocs {
+		stripDocs(fields)
+	}
+
+	signature, err := json.Marshal(fields) // map keys are sorted
+	if err != nil {
+		return "", false
+	}
+
+	return string(signature), true
+}
+
+// stripDocs removes titles and descriptions from the JSON fields of a schema and from its nested schemas.
+func stripDocs(fields map[string]any) {
+	delete(fields, "title")
+	delete(fields, "description")
+
+	for _, keyword := range []string{"properties", "patternProperties", "definitions"} {
+		schemas, _ := fields[keyword].(map[string]any)
+		for _, nested := range schemas {
+			stripNestedDocs(nested)
+		}
+	}
+
+	for _, keyword := range []string{"items", "allOf", "anyOf", "oneOf", "not", "additionalProperties", "additionalItems"} {
+		stripNestedDocs(fields[keyword])
+	}
+}
+
+// stripNestedDocs removes docs from a nested schema or array of schemas. Booleans are left alone.
+func stripNestedDocs(nested any) {
+	switch value := nested.(type) {
+	case map[string]any:
+		stripDocs(value)
+	case []any:
+		for _, item := range value {
+			stripNestedDocs(item)
+		}
+	}
+}
+
+// collapseDefinitions rewrites the $ref to duplicate definitions so they point to their canonical definition,
+// then removes the duplicates.
+func collapseDefinitions(opts *FlattenOpts, duplicates map[string]string) error {
+	removed := make([]string, 0, len(duplicates))
+	for _, name := range sortedKeys(duplicates) {
+		removed = append(removed, path.Join(definitionsPath, jsonpointer.Escape(name)))
+	}
+
+	for _, key := range sortedKeys(opts.Spec.references.allRefs) {
+		if slices.ContainsFunc(removed, func(duplicate string) bool { return isUnder(key, duplicate) }) {
+			continue
+		}
+
+		ref := opts.Spec.references.allRefs[key]
+		from := ref.String()
+		for _, name := range sortedKeys(duplicates) {
+			duplicate := path.Join(definitionsPath, jsonpointer.Escape(name))
+			if !isUnder(from, duplicate) {
+				continue
+			}
+
+			to := path.Join(definitionsPath, jsonpointer.Escape(duplicates[name])) + strings.TrimPrefix(from, duplicate)
+			debugLog("rewriting $ref to duplicate definition at %s: %s -> %s", key, from, to)
+			if err := replace.UpdateRef(opts.Swagger(), key, spec.MustCreateRef(to)); err != nil {
+				return ErrAtKey(key, err)
+			}
+			opts.flattenContext.record(FlattenRewritten, key, from, to)
+
+			break
+		}
+	}
+
+	for _, name := range sortedKeys(duplicates) {
+		delete(opts.Swagger().Definitions, name)
+		opts.flattenContext.record(FlattenDeduplicated,
+			path.Join(definitionsPath, jsonpointer.Escape(name)), "",
+			path.Join(definitionsPath, jsonpointer.Escape(duplicates[name])))
+	}
+
+	opts.Spec.reload() // re-analyze
+
+	return nil
+}
@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/analysis/internal/antest"
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestFlatten_DeduplicateDefinitions(t *testing.T) {
+	t.Parallel()
+
+	bp := filepath.Join("fixtures", "dedupe.yml")
+
+	t.Run("should collapse identical definitions", func(t *testing.T) {
+		t.Parallel()
+
+		doc := antest.LoadOrFail(t, bp)
+		report, err := FlattenWithReport(FlattenOpts{Spec: New(doc), BasePath: bp, Minimal: true, DeduplicateDefinitions: true})
+		require.NoError(t, err)
+
+		assert.NotContains(t, doc.Definitions, "petCopy")
+		assert.Contains(t, doc.Definitions, "pet")
+		assert.Contains(t, doc.Definitions, "animal") // descriptions differ
+
+		assert.Equal(t, []FlattenChange{
+			{Kind: FlattenDeduplicated, Key: "#/definitions/petCopy", To: "#/definitions/pet"},
+		}, report.ChangesAt("#/definitions/petCopy"))
+		assert.EqualT(t, "removed #/definitions/petCopy as a duplicate of #/definitions/pet",
+			report.ChangesAt("#/definitions/petCopy")[0].String())
+
+		response := doc.Paths.Paths["/pets"].Get.Responses.StatusCodeResponses[200]
+		assert.EqualT(t, "#/definitions/pet", response.Schema.Ref.String())
+		assert.Equal(t, []FlattenChange{
+			{Kind: FlattenRewritten, Key: "#/paths/~1pets/get/responses/200/schema", From: "#/definitions/petCopy", To: "#/definitions/pet"},
+		}, report.ChangesAt("#/paths/~1pets/get/responses/200/schema"))
+
+		t.Run("with definitions made identical by rewritten $ref collapsed as well", func(t *testing.T) {
+			assert.NotContains(t, doc.Definitions, "wrapperB")
+			assert.Contains(t, doc.Definitions, "wrapperA")
+		})
+
+		t.Run("with polymorphic definitions left untouched", func(t *testing.T) {
+			assert.Contains(t, doc.Definitions, "cat")
+			assert.Contains(t, doc.Definitions, "dog")
+		})
+	})
+
+	t.Run("should collapse definitions with different docs", func(t *testing.T) {
+		t.Parallel()
+
+		doc := antest.LoadOrFail(t, bp)
+		require.NoError(t, Flatten(FlattenOpts{
+			Spec: New(doc), BasePath: bp, Minimal: true, DeduplicateDefinitions: true, DeduplicateIgnoreDocs: true,
+		}))
+
+		assert.NotContains(t, doc.Definitions, "animal")
+		assert.NotContains(t, doc.Definitions, "petCopy")
+		pet, ok := doc.Definitions["pet"]
+		require.TrueT(t, ok)
+		assert.EqualT(t, "a pet", pet.Description)
+	})
+
+	t.Run("should collapse named inline schemas", func(t *testing.T) {
+		t.Parallel()
+
+		doc := antest.LoadOrFail(t, bp)
+		require.NoError(t, Flatten(FlattenOpts{Spec: New(doc), BasePath: bp, DeduplicateDefinitions: true}))
+
+		assert.Contains(t, doc.Definitions, "getaOKBody")
+		assert.NotContains(t, doc.Definitions, "getbOKBody")
+		response := doc.Paths.Paths["/b"].Get.Responses.StatusCodeResponses[200]
+		assert.EqualT(t, "#/definitions/getaOKBody", response.Schema.Ref.String())
+	})
+
+	t.Run("should keep identical definitions by default", func(t *testing.T) {
+		t.Parallel()
+
+		doc := antest.LoadOrFail(t, bp)
+		require.NoError(t, Flatten(FlattenOpts{Spec: New(doc), BasePath: bp, Minimal: true}))
+
+		assert.Contains(t, doc.Definitions, "petCopy")
+		assert.Contains(t, doc.Definitions, "wrapperB")
+	})
+}
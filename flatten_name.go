@@ -13,10 +13,68 @@ import (
 	"github.com/go-openapi/analysis/internal/flatten/replace"
 	"github.com/go-openapi/analysis/internal/flatten/schutils"
 	"github.com/go-openapi/analysis/internal/flatten/sortref"
+	"github.com/go-openapi/jsonpointer"
 	"github.com/go-openapi/spec"
 	"github.com/go-openapi/swag/mangling"
 )
 
+const schemaNameExtension = "x-schema-name"
+
+// SplitKey is a JSON pointer split into its unescaped parts, e.g. ["paths", "/pets", "post", "parameters", "0", "schema"].
+type SplitKey = sortref.SplitKey
+
+// NamingStrategy names the inline schemas moved to new definitions when flattening a spec.
+//
+// See [FlattenOpts.NamingStrategy].
+type NamingStrategy interface {
+	// InlineSchemaName yields the name of the definition created for an inline schema.
+	//
+	// When an empty name is returned, the default names are used (see [InlineSchema.DefaultNames]).
+	InlineSchemaName(InlineSchema) string
+}
+
+// NamingStrategyFunc is a function used as a [NamingStrategy].
+type NamingStrategyFunc func(InlineSchema) string
+
+// InlineSchemaName calls f(inline).
+func (f NamingStrategyFunc) InlineSchemaName(inline InlineSchema) string {
+	return f(inline)
+}
+
+// InlineSchema describes an inline schema about to be moved to a new definition by [Flatten].
+type InlineSchema struct {
+	// Key is the JSON pointer to the inline schema, e.g. "#/paths/~1pets/post/parameters/0/schema".
+	Key string
+
+	// Parts of the key.
+	Parts SplitKey
+
+	Schema   *spec.Schema
+	Analyzed *AnalyzedSchema
+
+	// Operations the inline schema belongs to, if any: the operation of a parameter or a response,
+	// or all the operations of a path for a parameter shared at the path level.
+	Operations []InlineSchemaOperation
+
+	// Definitions already known. They must not be altered.
+	Definitions spec.Definitions
+
+	// DefaultNames are the names used by default, derived from the key: e.g. the operation ID with
+	// "Body", "ParamsBody" or the status code, or the parent definition with the property name.
+	DefaultNames []string
+}
+
+// InlineSchemaOperation is an operation an [InlineSchema] belongs to.
+type InlineSchemaOperation struct {
+	Method string
+	Path   string
+
+	// ID of the operation, or a name derived from its method and path when it has no operationId.
+	ID string
+
+	Operation *spec.Operation
+}
+
 // InlineSchemaNamer finds a new name for an inlined type.
 type InlineSchemaNamer struct {
 	Spec           *spec.Swagger
@@ -30,58 +88,61 @@ func (isn *InlineSchemaNamer) Name(key string, schema *spec.Schema, aschema *Ana
 	debugLog("naming inlined schema at %s", key)
 
 	parts := sortref.KeyParts(key)
-	for _, name := range namesFromKey(parts, aschema, isn.Operations) {
-		if name == "" {
-			continue
-		}
-
+	for _, name := range isn.namesFor(key, parts, schema, aschema) {
 		// create unique name
-		mangle := mangler(isn.opts)
-		newName, isOAIGen := uniqifyName(isn.Spec.Definitions, mangle(name))
+		newName, isOAIGen := uniqifyName(isn.Spec.Definitions, name)
 
-		// clone schema
+		// names from an extension or a naming strategy may contain characters to escape in a $ref
+		target := path.Join(definitionsPath, jsonpointer.Escape(newName))
+		ref, err := spec.NewRef(target)
+		if err != nil {
+			return ErrInlineDefinition(newName, err)
+		}
+
+		// clone schema, without the name which is now conveyed by the definition
 		sch := schutils.Clone(schema)
+		delete(sch.Extensions, schemaNameExtension)
 
 		// replace values on schema
 		debugLog("rewriting schema to ref: key=%s with new name: %s", key, newName)
-		if err := replace.RewriteSchemaToRef(isn.Spec, key,
-			spec.MustCreateRef(path.Join(definitionsPath, newName))); err != nil {
+		if err := replace.RewriteSchemaToRef(isn.Spec, key, ref); err != nil {
 			return ErrInlineDefinition(newName, err)
 		}
+		isn.opts.Spec.Reanalyze(key)
+		isn.flattenContext.record(FlattenNamed, key, "", target)
 
 		// rewrite any dependent $ref pointing to this place,
 		// when not already pointing to a top-level definition.
 		//
 		// NOTE: this is important if such referers use arbitrary JSON pointers.
-		an := New(isn.Spec)
-		for k, v := range an.references.allRefs {
+		for k, v := range refsChainedTo(isn.opts.Spec.references.allRefs, key) {
 			r, erd := replace.DeepestRef(isn.opts.Swagger(), isn.opts.ExpandOpts(false), v)
 			if erd != nil {
 				return ErrAtKey(k, erd)
 			}
 
-			if isn.opts.flattenContext != nil {
-				isn.opts.flattenContext.warnings = append(isn.opts.flattenContext.warnings, r.Warnings...)
-			}
+			isn.opts.flattenContext.warnRefs(k, r.Warnings)
 
-			if r.Ref.String() != key && (r.Ref.String() != path.Join(definitionsPath, newName) || path.Dir(v.String()) == definitionsPath) {
+			if r.Ref.String() != key && (r.Ref.String() != target || path.Dir(v.String()) == definitionsPath) {
 				continue
 			}
 
 			debugLog("found a $ref to a rewritten schema: %s points to %s", k, v.String())
 
 			// rewrite $ref to the new target
-			if err := replace.UpdateRef(isn.Spec, k,
-				spec.MustCreateRef(path.Join(definitionsPath, newName))); err != nil {
+			if err := replace.UpdateRef(isn.Spec, k, ref); err != nil {
 				return err
 			}
+			isn.opts.Spec.Reanalyze(k)
+			isn.flattenContext.record(FlattenRewritten, k, v.String(), target)
 		}
 
 		// NOTE: this extension is currently not used by go-swagger (provided for information only)
-		sch.AddExtension("x-go-gen-location", GenLocation(parts))
+		sch.AddExtension(genLocationExtension, GenLocation(parts))
 
 		// save cloned schema to definitions
 		schutils.Save(isn.Spec, newName, sch)
+		isn.opts.Spec.Reanalyze(target)
 
 		// keep track of created refs
 		if isn.flattenContext == nil {
@@ -98,7 +159,7 @@ func (isn *InlineSchemaNamer) Name(key string, schema *spec.Schema, aschema *Ana
 		isn.flattenContext.newRefs[key] = &newRef{
 			key:      key,
 			newName:  newName,
-			path:     path.Join(definitionsPath, newName),
+			path:     target,
 			isOAIGen: isOAIGen,
 			resolved: resolved,
 			schema:   sch,
@@ -108,6 +169,87 @@ func (isn *InlineSchemaNamer) Name(key string, schema *spec.Schema, aschema *Ana
 	return nil
 }
 
+// refsChainedTo yields the $ref pointing to key, either directly or through a chain of other $ref.
+//
+// Only these may resolve to key: other $ref need not be resolved.
+func refsChainedTo(refs map[string]spec.Ref, key string) map[string]spec.Ref {
+	chained := make(map[string]spec.Ref, allocSmallMap)
+	targets := []string{key}
+	for len(targets) > 0 {
+		target := targets[len(targets)-1]
+		targets = targets[:len(targets)-1]
+
+		for k, v := range refs {
+			if _, found := chained[k]; found || v.String() != target {
+				continue
+			}
+
+			chained[k] = v
+			targets = append(targets, k)
+		}
+	}
+
+	return chained
+}
+
+// namesFor yields the names of the definitions created for an inline schema: the x-schema-name extension
+// of the schema if any, otherwise the name from the naming strategy if any, otherwise the default names.
+func (isn *InlineSchemaNamer) namesFor(key string, parts sortref.SplitKey, schema *spec.Schema, aschema *AnalyzedSchema) []string {
+	if name, ok := schema.Extensions.GetString(schemaNameExtension); ok && name != "" {
+		return []string{name}
+	}
+
+	mangle := mangler(isn.opts)
+	defaults := make([]string, 0, 1)
+	for _, name := range namesFromKey(parts, aschema, isn.Operations) {
+		if name != "" {
+			defaults = append(defaults, mangle(name))
+		}
+	}
+
+	if isn.opts.NamingStrategy == nil {
+		return defaults
+	}
+
+	name := isn.opts.NamingStrategy.InlineSchemaName(InlineSchema{
+		Key:          key,
+		Parts:        parts,
+		Schema:       schema,
+		Analyzed:     aschema,
+		Operations:   operationsForKey(parts, isn.Operations),
+		Definitions:  isn.Spec.Definitions,
+		DefaultNames: defaults,
+	})
+	if name == "" {
+		return defaults
+	}
+
+	return []string{name}
+}
+
+// operationsForKey yields the operations an inline schema belongs to, sorted by path and method.
+func operationsForKey(parts sortref.SplitKey, operations map[string]operations.OpRef) []InlineSchemaOperation {
+	if !parts.IsOperation() {
+		return nil
+	}
+
+	var result []InlineSchemaOperation
+	piref := parts.PathItemRef()
+	for _, op := range operations {
+		if op.Path != parts[1] || (piref.String() != "" && op.Ref.String() != piref.String()) {
+			continue
+		}
+
+		result = append(result, InlineSchemaOperation{Method: op.Method, Path: op.Path, ID: op.ID, Operation: op.Op})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Path < result[j].Path || (result[i].Path == result[j].Path && result[i].Method < result[j].Method)
+	})
+
+	return result
+}
+
 // uniqifyName yields a unique name for a definition.
 func uniqifyName(definitions spec.Definitions, name string) (string, bool) {
 	isOAIGen := false
@@ -5,6 +5,7 @@ package analysis
 
 import (
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
@@ -519,6 +520,26 @@ func TestName_InlinedSchemas(t *testing.T) {
 	}
 }
 
+func TestName_RefsChainedTo(t *testing.T) {
+	t.Parallel()
+
+	const key = "#/paths/~1pets/get/responses/200/schema"
+	refs := map[string]spec.Ref{
+		"#/definitions/a":                   spec.MustCreateRef(key),
+		"#/definitions/b":                   spec.MustCreateRef("#/definitions/a"),
+		"#/definitions/c":                   spec.MustCreateRef("#/definitions/b"),
+		"#/definitions/d":                   spec.MustCreateRef(key + "/properties/name"),
+		"#/definitions/e":                   spec.MustCreateRef("#/definitions/other"),
+		"#/definitions/other/properties/id": spec.MustCreateRef("#/definitions/e"),
+	}
+
+	chained := refsChainedTo(refs, key)
+	assert.Len(t, chained, 3)
+	for _, k := range []string{"#/definitions/a", "#/definitions/b", "#/definitions/c"} {
+		assert.Containsf(t, chained, k, "expected %s to be chained to %s", k, key)
+	}
+}
+
 func TestFlattenSchema_UnitGuards(t *testing.T) {
 	t.Parallel()
 
@@ -534,3 +555,97 @@ func definitionPtr(key string) string {
 
 	return strings.Join(strings.Split(key, "/")[:3], "/")
 }
+
+func TestFlatten_NamingStrategy(t *testing.T) {
+	t.Parallel()
+
+	bp := filepath.Join("fixtures", "naming-strategy.yml")
+
+	t.Run("should name inline schemas with a custom strategy", func(t *testing.T) {
+		t.Parallel()
+
+		doc := antest.LoadOrFail(t, bp)
+		var inlines []InlineSchema
+		strategy := NamingStrategyFunc(func(inline InlineSchema) string {
+			inlines = append(inlines, inline)
+			if len(inline.Operations) != 1 {
+				return ""
+			}
+
+			id := inline.Operations[0].ID
+			pascalized := strings.ToUpper(id[:1]) + id[1:]
+			switch {
+			case inline.Parts.IsOperationParam():
+				return pascalized + "Request"
+			case inline.Parts.IsStatusCodeResponse():
+				return pascalized + inline.Parts[4] + "Response"
+			default:
+				return ""
+			}
+		})
+
+		require.NoError(t, Flatten(FlattenOpts{Spec: New(doc), BasePath: bp, NamingStrategy: strategy}))
+
+		for _, name := range []string{"CreatePetRequest", "CreatePet200Response", "PetDetails", "petOwner"} {
+			assert.Containsf(t, doc.Definitions, name, "expected definition %s", name)
+		}
+		param := doc.Paths.Paths["/pets"].Post.Parameters[0]
+		assert.EqualT(t, "#/definitions/CreatePetRequest", param.Schema.Ref.String())
+
+		t.Run("with the context of inline schemas", func(t *testing.T) {
+			index := slices.IndexFunc(inlines, func(inline InlineSchema) bool {
+				return inline.Key == "#/paths/~1pets/post/responses/200/schema"
+			})
+			require.GreaterOrEqualT(t, index, 0)
+			inline := inlines[index]
+
+			require.Len(t, inline.Operations, 1)
+			assert.EqualT(t, "POST", inline.Operations[0].Method)
+			assert.EqualT(t, "/pets", inline.Operations[0].Path)
+			assert.EqualT(t, "createPet", inline.Operations[0].ID)
+			assert.Equal(t, SplitKey{"paths", "/pets", "post", "responses", "200", "schema"}, inline.Parts)
+			assert.Equal(t, []string{"createPetOKBody"}, inline.DefaultNames)
+			assert.Contains(t, inline.Schema.Properties, "id")
+			assert.NotNil(t, inline.Analyzed)
+			assert.Contains(t, inline.Definitions, "pet")
+		})
+
+		t.Run("with x-schema-name taking precedence", func(t *testing.T) {
+			assert.False(t, slices.ContainsFunc(inlines, func(inline InlineSchema) bool {
+				return inline.Key == "#/paths/~1pets~1{id}/get/responses/200/schema"
+			}))
+		})
+	})
+
+	t.Run("should honor x-schema-name by default", func(t *testing.T) {
+		t.Parallel()
+
+		doc := antest.LoadOrFail(t, bp)
+		require.NoError(t, Flatten(FlattenOpts{Spec: New(doc), BasePath: bp}))
+
+		for _, name := range []string{"createPetParamsBody", "createPetOKBody", "PetDetails", "petOwner"} {
+			assert.Containsf(t, doc.Definitions, name, "expected definition %s", name)
+		}
+		assert.NotContains(t, doc.Definitions["PetDetails"].Extensions, schemaNameExtension)
+	})
+
+	t.Run("should escape names in $ref", func(t *testing.T) {
+		t.Parallel()
+
+		doc := antest.LoadOrFail(t, bp)
+		response := doc.Paths.Paths["/pets/{id}"].Get.Responses.StatusCodeResponses[200]
+		response.Schema.AddExtension(schemaNameExtension, "pets/Detail")
+		doc.Paths.Paths["/pets/{id}"].Get.Responses.StatusCodeResponses[200] = response
+
+		require.NoError(t, Flatten(FlattenOpts{Spec: New(doc), BasePath: bp}))
+
+		require.Contains(t, doc.Definitions, "pets/Detail")
+		assert.NotContains(t, doc.Definitions["pets/Detail"].Extensions, schemaNameExtension)
+		response = doc.Paths.Paths["/pets/{id}"].Get.Responses.StatusCodeResponses[200]
+		assert.EqualT(t, "#/definitions/pets~1Detail", response.Schema.Ref.String())
+
+		resolved, err := spec.ResolveRef(doc, &response.Schema.Ref)
+		require.NoError(t, err)
+		assert.Contains(t, resolved.Properties, "name")
+	})
+}
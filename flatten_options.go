@@ -5,8 +5,9 @@ package analysis
 
 import (
 	"encoding/json"
-	"log"
+	"path"
 
+	"github.com/go-openapi/jsonpointer"
 	"github.com/go-openapi/spec"
 	"github.com/go-openapi/swag/loading"
 	"github.com/go-openapi/swag/mangling"
@@ -29,12 +30,36 @@ type FlattenOpts struct {
 	// Flattening options
 	Expand          bool              // When true, skip flattening the spec and expand it instead (if Minimal is false)
 	Minimal         bool              // When true, do not decompose complex structures such as allOf
-	Verbose         bool              // enable some reporting on possible name conflicts detected
+	Verbose         bool              // enable logging of warnings, e.g. on possible name conflicts detected
 	RemoveUnused    bool              // When true, remove unused parameters, responses and definitions after expansion/flattening
 	ContinueOnError bool              // Continue when spec expansion issues are found
 	KeepNames       bool              // Do not attempt to jsonify names from references when flattening
 	ManglerOpts     []mangling.Option `json:"-"` // Options for the name mangler used to jsonify names
 
+	// SimplifyAllOf merges inline allOf members into their parent schema, whenever the merged schema
+	// validates like the original composition: e.g. validation-only or extensions-only members, or the single
+	// member of an allOf wrapper. Required properties are merged. An allOf left with a single $ref and
+	// nothing else is replaced by this $ref.
+	//
+	// Definitions which take part in a polymorphic hierarchy, i.e. discriminated types and their subtypes,
+	// are left untouched.
+	//
+	// Simplifications are reported as [FlattenMerged] and [FlattenLifted] changes.
+	SimplifyAllOf bool
+
+	// DeduplicateDefinitions collapses structurally identical definitions into a single one, e.g. "Pet" and
+	// "PetOAIGen" resulting from flattening a spec assembled from several documents. All $ref to the duplicates
+	// are rewritten to the definition which is kept, and the duplicates are removed.
+	//
+	// Definitions from the original spec are kept in preference to the ones created by flatten,
+	// then the shortest name is kept. Definitions which take part in a polymorphic hierarchy are never collapsed.
+	//
+	// Duplicates are reported as [FlattenDeduplicated] changes.
+	DeduplicateDefinitions bool
+
+	// DeduplicateIgnoreDocs ignores titles and descriptions when comparing definitions with DeduplicateDefinitions.
+	DeduplicateIgnoreDocs bool
+
 	// PathLoaderWithOptions injects the document loader used to resolve remote and relative $ref
 	// while flattening or expanding the specification. It matches the option-aware loader signature
 	// of github.com/go-openapi/swag/loading (and go-openapi/loads).
@@ -45,6 +70,22 @@ type FlattenOpts struct {
 	// go-openapi/loads. Left nil, the spec package default (unsandboxed) loader is used.
 	PathLoaderWithOptions func(string, ...loading.Option) (json.RawMessage, error) `json:"-"`
 
+	// Positions of the constructs of the spec in its source documents, used to locate the issues
+	// reported by [FlattenWithIssues]. When nil, the positions the spec is analyzed with are used, if any
+	// (see [WithPositions]).
+	//
+	// Issues about constructs missing from the source documents, e.g. definitions created by flatten,
+	// are located at their closest enclosing construct, as with [Spec.Issues].
+	Positions *PositionMap `json:"-"`
+
+	// NamingStrategy names the inline schemas moved to new definitions with full flattening.
+	// When nil, or when the strategy yields no name, names are derived from the location of the schema.
+	//
+	// Names from the strategy are used verbatim, i.e. they are not mangled. In any case, an inline schema
+	// with a "x-schema-name" extension gets this name, and the extension is removed from the new definition.
+	// Names conflicting with an existing definition get a generated suffix.
+	NamingStrategy NamingStrategy `json:"-"`
+
 	/* Extra keys */
 	_ struct{} // require keys
 }
@@ -64,34 +105,46 @@ func (f *FlattenOpts) Swagger() *spec.Swagger {
 	return f.Spec.spec
 }
 
-// croak logs notifications and warnings about valid, but possibly unwanted constructs resulting
+// issues reports notifications and warnings about valid, but possibly unwanted constructs resulting
 // from flattening a spec.
-func (f *FlattenOpts) croak() {
-	if !f.Verbose {
-		return
-	}
-
+func (f *FlattenOpts) issues() []Issue {
+	issues := make([]Issue, 0, len(f.flattenContext.warnings))
 	reported := make(map[string]bool, len(f.flattenContext.newRefs))
 	for _, v := range f.Spec.references.allRefs {
 		// warns about duplicate handling
 		for _, r := range f.flattenContext.newRefs {
-			if r.isOAIGen && r.path == v.String() {
+			if r.isOAIGen && r.path == v.String() && !reported[r.newName] {
 				reported[r.newName] = true
+				issues = append(issues, Issue{
+					Severity: SeverityWarning,
+					Code:     IssueFlattenNameConflict,
+					Pointer:  path.Join(definitionsPath, jsonpointer.Escape(r.newName)),
+					Message:  "duplicate flattened definition name resolved as " + r.newName,
+				})
 			}
 		}
 	}
 
-	for k := range reported {
-		log.Printf("warning: duplicate flattened definition name resolved as %s", k)
-	}
-
 	// warns about possible type mismatches
-	uniqueMsg := make(map[string]bool)
-	for _, msg := range f.flattenContext.warnings {
-		if _, ok := uniqueMsg[msg]; ok {
+	type uniqueKey struct{ pointer, msg string }
+	uniqueMsg := make(map[uniqueKey]bool, len(f.flattenContext.warnings))
+	for _, issue := range f.flattenContext.warnings {
+		key := uniqueKey{pointer: issue.Pointer, msg: issue.Message}
+		if uniqueMsg[key] {
 			continue
 		}
-		log.Printf("warning: %s", msg)
-		uniqueMsg[msg] = true
+		issues = append(issues, issue)
+		uniqueMsg[key] = true
 	}
+
+	sortIssues(issues)
+
+	positions := f.Positions
+	if positions == nil {
+		positions = f.Spec.positions
+	}
+
+	locateIssues(issues, positions)
+
+	return issues
 }
@@ -0,0 +1,184 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"fmt"
+	"log"
+	"path"
+
+	"github.com/go-openapi/jsonpointer"
+	"github.com/go-openapi/spec"
+)
+
+// FlattenChangeKind qualifies a change performed on a spec by [Flatten].
+type FlattenChangeKind string
+
+const (
+	// FlattenExpanded denotes a $ref replaced by the construct it points to.
+	FlattenExpanded FlattenChangeKind = "expanded"
+
+	// FlattenImported denotes a remote $ref imported as a local definition.
+	FlattenImported FlattenChangeKind = "imported"
+
+	// FlattenNamed denotes an inline schema moved to a new definition.
+	FlattenNamed FlattenChangeKind = "named"
+
+	// FlattenRewritten denotes a $ref rewritten to point to another location.
+	FlattenRewritten FlattenChangeKind = "rewritten"
+
+	// FlattenMerged denotes a schema merged into another one: either a definition created to resolve
+	// a name conflict, then merged back into its parent, or an allOf member merged into its parent schema
+	// (see [FlattenOpts.SimplifyAllOf]).
+	FlattenMerged FlattenChangeKind = "merged"
+
+	// FlattenLifted denotes an allOf wrapping a single $ref, replaced by this $ref (see [FlattenOpts.SimplifyAllOf]).
+	FlattenLifted FlattenChangeKind = "lifted"
+
+	// FlattenDeduplicated denotes a definition removed as a duplicate of another, identical definition
+	// (see [FlattenOpts.DeduplicateDefinitions]).
+	FlattenDeduplicated FlattenChangeKind = "deduplicated"
+
+	// FlattenRemoved denotes an unused definition removed from the spec, or a shared parameter or response
+	// removed once expanded (see [FlattenOpts.RemoveUnused]).
+	FlattenRemoved FlattenChangeKind = "removed"
+)
+
+// FlattenChange describes a change performed on a spec by [Flatten].
+type FlattenChange struct {
+	Kind FlattenChangeKind `json:"kind"`
+
+	// Key is the JSON pointer to the changed location, e.g. "#/definitions/Pet/properties/owner".
+	Key string `json:"key"`
+
+	// From is the $ref found at Key before the change, if any.
+	From string `json:"from,omitempty"`
+
+	// To is the $ref found at Key after the change, if any.
+	//
+	// For [FlattenNamed] changes, this is the new definition. For [FlattenMerged] changes, this is the
+	// parent the schema at Key is merged into. For [FlattenDeduplicated] changes, this is the definition kept
+	// in place of the definition at Key.
+	To string `json:"to,omitempty"`
+}
+
+func (c FlattenChange) String() string {
+	switch c.Kind {
+	case FlattenExpanded:
+		return fmt.Sprintf("expanded $ref %q at %s", c.From, c.Key)
+	case FlattenImported:
+		return fmt.Sprintf("imported $ref %q at %s as %s", c.From, c.Key, c.To)
+	case FlattenNamed:
+		return fmt.Sprintf("named inline schema at %s as %s", c.Key, c.To)
+	case FlattenRewritten:
+		return fmt.Sprintf("rewritten $ref at %s from %q to %q", c.Key, c.From, c.To)
+	case FlattenMerged:
+		return fmt.Sprintf("merged %s into %s", c.Key, c.To)
+	case FlattenLifted:
+		return fmt.Sprintf("lifted allOf at %s as $ref %q", c.Key, c.To)
+	case FlattenDeduplicated:
+		return fmt.Sprintf("removed %s as a duplicate of %s", c.Key, c.To)
+	case FlattenRemoved:
+		if isUnder(c.Key, definitionsPath) {
+			return "removed unused " + c.Key
+		}
+
+		return "removed expanded " + c.Key
+	default:
+		return fmt.Sprintf("%s %s", c.Kind, c.Key)
+	}
+}
+
+// FlattenReport describes the outcome of flattening a spec.
+type FlattenReport struct {
+	// Changes performed on the spec, in the order they were carried out.
+	//
+	// The same location may be changed several times, e.g. a remote $ref imported then rewritten.
+	Changes []FlattenChange `json:"changes"`
+
+	// Issues are the warnings about valid, but possibly unwanted constructs resulting from flattening the spec,
+	// sorted by pointer, then by code.
+	Issues []Issue `json:"issues"`
+}
+
+// ChangesAt returns the changes performed at a given location, in the order they were carried out.
+func (r FlattenReport) ChangesAt(key string) []FlattenChange {
+	var changes []FlattenChange
+	for _, change := range r.Changes {
+		if change.Key == key {
+			changes = append(changes, change)
+		}
+	}
+
+	return changes
+}
+
+// FlattenWithReport flattens an analyzed spec like [Flatten], and returns a report of all the changes
+// performed on the spec, along with the warnings issued.
+//
+// Nothing is logged, regardless of the Verbose option.
+func FlattenWithReport(opts FlattenOpts) (*FlattenReport, error) {
+	if err := flatten(&opts); err != nil {
+		return nil, err
+	}
+
+	return &FlattenReport{
+		Changes: opts.flattenContext.changes,
+		Issues:  opts.issues(),
+	}, nil
+}
+
+// log the report, as with the Verbose option.
+func (r FlattenReport) log() {
+	for _, change := range r.Changes {
+		// shared parameters and responses are removed because they are expanded, not because they are unused
+		if change.Kind == FlattenRemoved && isUnder(change.Key, definitionsPath) {
+			log.Printf("info: removing unused definition: %s", path.Base(change.Key))
+		}
+	}
+
+	// issues are reported for every pointer, but the same message is logged once
+	logged := make(map[string]bool, len(r.Issues))
+	for _, issue := range r.Issues {
+		msg := fmt.Sprintf("%s: %s", issue.Severity, issue.Message)
+		if logged[msg] {
+			continue
+		}
+		logged[msg] = true
+		log.Print(msg)
+	}
+}
+
+// record a change, if changes are tracked.
+func (c *context) record(kind FlattenChangeKind, key string, from, to string) {
+	if c == nil {
+		return
+	}
+
+	c.changes = append(c.changes, FlattenChange{Kind: kind, Key: key, From: from, To: to})
+}
+
+// recordRemoved records the removal of all the entries of a section of the spec.
+func recordRemoved[T any](c *context, section string, entries map[string]T) {
+	for _, name := range sortedKeys(entries) {
+		c.record(FlattenRemoved, path.Join(section, jsonpointer.Escape(name)), "", "")
+	}
+}
+
+// expandableRefs yields the $ref which are going to be expanded: all of them when expanding the whole spec,
+// otherwise all the $ref which are not in schemas.
+func expandableRefs(opts *FlattenOpts) map[string]spec.Ref {
+	if opts.Expand {
+		return opts.Spec.references.allRefs
+	}
+
+	refs := make(map[string]spec.Ref, allocMediumMap)
+	for key, ref := range opts.Spec.references.allRefs {
+		if _, isSchema := opts.Spec.references.schemas[key]; !isSchema {
+			refs[key] = ref
+		}
+	}
+
+	return refs
+}
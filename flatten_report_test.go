@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-openapi/analysis/internal/antest"
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestFlattenWithReport(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should report changes with full flattening", func(t *testing.T) {
+		t.Parallel()
+
+		bp := filepath.Join("fixtures", "flatten.yml")
+		doc := antest.LoadOrFail(t, bp)
+
+		report, err := FlattenWithReport(FlattenOpts{Spec: New(doc), BasePath: bp, RemoveUnused: true})
+		require.NoError(t, err)
+		require.NotNil(t, report)
+		assert.Empty(t, report.Issues)
+
+		assert.Equal(t, []FlattenChange{
+			{Kind: FlattenExpanded, Key: "#/paths/~1some~1where~1{id}/get/parameters/1", From: "#/parameters/someParam"},
+		}, report.ChangesAt("#/paths/~1some~1where~1{id}/get/parameters/1"))
+
+		assert.Equal(t, []FlattenChange{
+			{Kind: FlattenImported, Key: "#/definitions/namedAgain", From: "external/definitions.yml#/definitions/named", To: "#/definitions/named"},
+			{Kind: FlattenRemoved, Key: "#/definitions/namedAgain"},
+		}, report.ChangesAt("#/definitions/namedAgain"))
+
+		named := report.ChangesAt("#/paths/~1some~1where~1{id}/get/responses/200/schema")
+		require.Len(t, named, 1)
+		assert.EqualT(t, FlattenNamed, named[0].Kind)
+		assert.EqualT(t, "#/definitions/getSomeWhereIdOKBody", named[0].To)
+		assert.EqualT(t, "named inline schema at #/paths/~1some~1where~1{id}/get/responses/200/schema as #/definitions/getSomeWhereIdOKBody",
+			named[0].String())
+
+		for key, description := range map[string]string{
+			"#/parameters/someParam":   "removed expanded #/parameters/someParam",
+			"#/responses/notFound":     "removed expanded #/responses/notFound",
+			"#/definitions/namedThing": "removed unused #/definitions/namedThing",
+		} {
+			removed := report.ChangesAt(key)
+			require.Lenf(t, removed, 1, "expected %s to be removed", key)
+			assert.EqualT(t, FlattenRemoved, removed[0].Kind)
+			assert.EqualT(t, description, removed[0].String())
+		}
+		_, stillThere := doc.Definitions["getSomeWhereIdOKBody"]
+		assert.TrueT(t, stillThere)
+	})
+
+	t.Run("should report resolved name conflicts", func(t *testing.T) {
+		t.Parallel()
+
+		bp := filepath.Join("fixtures", "oaigen", "fixture-oaigen.yaml")
+		doc := antest.LoadOrFail(t, bp)
+
+		report, err := FlattenWithReport(FlattenOpts{Spec: New(doc), BasePath: bp})
+		require.NoError(t, err)
+		require.Len(t, report.Issues, 1)
+
+		assert.Equal(t, []FlattenChange{
+			{Kind: FlattenImported, Key: "#/definitions/b/items", From: "transitive-2.yaml#/definitions/b", To: "#/definitions/bOAIGen1"},
+			{Kind: FlattenRewritten, Key: "#/definitions/b/items", From: "#/definitions/bOAIGen1", To: "#/definitions/d"},
+		}, report.ChangesAt("#/definitions/b/items"))
+
+		assert.Equal(t, []FlattenChange{
+			{Kind: FlattenMerged, Key: "#/definitions/bOAIGen1", To: "#/definitions/d"},
+		}, report.ChangesAt("#/definitions/bOAIGen1"))
+
+		expanded := report.ChangesAt("#/paths/~1some~1where/post/responses/default/schema")
+		require.NotEmpty(t, expanded)
+		assert.Equal(t, FlattenChange{
+			Kind: FlattenExpanded,
+			Key:  "#/paths/~1some~1where/post/responses/default/schema",
+			From: "#/definitions/myDefaultResponse/properties/zzz",
+		}, expanded[len(expanded)-1])
+	})
+
+	t.Run("should only report expanded parameters with minimal flattening", func(t *testing.T) {
+		t.Parallel()
+
+		bp := filepath.Join("fixtures", "routes.yml")
+		doc := antest.LoadOrFail(t, bp)
+
+		report, err := FlattenWithReport(FlattenOpts{Spec: New(doc), BasePath: bp, Minimal: true})
+		require.NoError(t, err)
+		assert.Equal(t, []FlattenChange{
+			{Kind: FlattenExpanded, Key: "#/paths/~1pets~1{name}/get/parameters/0", From: "#/parameters/petName"},
+		}, report.Changes)
+	})
+}
+
+func TestFlattenReport_Log(t *testing.T) {
+	report := FlattenReport{
+		Changes: []FlattenChange{
+			{Kind: FlattenRemoved, Key: "#/parameters/someParam"},
+			{Kind: FlattenRemoved, Key: "#/responses/notFound"},
+			{Kind: FlattenRemoved, Key: "#/definitions/namedThing"},
+		},
+		Issues: []Issue{
+			{Severity: SeverityWarning, Pointer: "#/definitions/a", Message: "some warning"},
+			{Severity: SeverityWarning, Pointer: "#/definitions/b", Message: "some warning"},
+		},
+	}
+
+	var logCapture bytes.Buffer
+	log.SetOutput(&logCapture)
+	defer log.SetOutput(os.Stdout)
+
+	report.log()
+
+	msg := logCapture.String()
+	assert.StringContainsT(t, msg, "info: removing unused definition: namedThing")
+	assert.StringNotContainsT(t, msg, "someParam")
+	assert.StringNotContainsT(t, msg, "notFound")
+	assert.EqualT(t, 1, strings.Count(msg, "warning: some warning"))
+}
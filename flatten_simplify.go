@@ -0,0 +1,238 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/analysis/internal/flatten/replace"
+	"github.com/go-openapi/analysis/internal/flatten/sortref"
+	"github.com/go-openapi/jsonpointer"
+	"github.com/go-openapi/spec"
+	"github.com/go-openapi/swag/jsonutils"
+)
+
+// keywords which prevent an allOf member from being merged into its parent schema.
+var unmergeableKeywords = []string{ //nolint:gochecknoglobals // quasi-constant
+	"$ref", "allOf", "anyOf", "oneOf", "not", "discriminator", "definitions", "id", "$schema",
+}
+
+// simplifyAllOfs merges inline allOf members into their parent schema whenever this may be done
+// without altering the validation of the schema, then lifts allOf compositions left with a single $ref.
+//
+// Definitions which take part in a polymorphic hierarchy (discriminated types and their subtypes)
+// are left untouched.
+func simplifyAllOfs(opts *FlattenOpts) error {
+	debugLog("simplifyAllOfs")
+
+	polymorphic := opts.Spec.polymorphicDefinitions()
+	altered := false
+	// nested allOfs are simplified first, so their parents are simplified with the outcome
+	for _, key := range sortref.DepthFirst(opts.Spec.allOfs) {
+		if _, isPolymorphic := polymorphic[key]; isPolymorphic {
+			continue
+		}
+
+		sch, ok := schemaAt(opts.Swagger(), key)
+		if !ok || len(sch.AllOf) == 0 {
+			continue
+		}
+
+		simplified, ok := simplifyAllOf(opts.flattenContext, key, sch)
+		if !ok {
+			continue
+		}
+
+		if err := replace.UpdateRefWithSchema(opts.Swagger(), key, simplified); err != nil {
+			return ErrAtKey(key, err)
+		}
+		altered = true
+	}
+
+	if altered {
+		opts.Spec.reload() // re-analyze
+	}
+
+	return nil
+}
+
+// simplifyAllOf simplifies the allOf of the schema located at key, and records the changes.
+//
+// It returns false when the schema is left unchanged.
+func simplifyAllOf(ctx *context, key string, sch *spec.Schema) (*spec.Schema, bool) {
+	var parent map[string]any
+	if err := jsonutils.FromDynamicJSON(sch, &parent); err != nil {
+		return nil, false
+	}
+	delete(parent, "allOf")
+
+	remaining := make([]spec.Schema, 0, len(sch.AllOf))
+	var (
+		merged  []string
+		lastRef string
+		isPure  bool
+	)
+
+	for i, member := range sch.AllOf {
+		var fields map[string]any
+		if err := jsonutils.FromDynamicJSON(member, &fields); err != nil {
+			return nil, false
+		}
+
+		withMember, ok := mergeSchemaFields(parent, fields)
+		if !ok {
+			remaining = append(remaining, member)
+			_, hasRef := fields["$ref"]
+			isPure = hasRef && len(fields) == 1
+			lastRef = member.Ref.String()
+
+			continue
+		}
+
+		parent = withMember
+		merged = append(merged, key+"/allOf/"+strconv.Itoa(i))
+	}
+
+	lifted := ""
+	if len(remaining) == 1 && len(parent) == 0 && isPure && lastRef != key {
+		// the allOf only wraps a $ref, which may replace it
+		lifted = lastRef
+	}
+
+	if len(merged) == 0 && lifted == "" {
+		return nil, false
+	}
+
+	var simplified spec.Schema
+	if err := jsonutils.FromDynamicJSON(parent, &simplified); err != nil {
+		return nil, false
+	}
+
+	if lifted != "" {
+		simplified = *spec.RefSchema(lifted)
+	} else if len(remaining) > 0 {
+		simplified.AllOf = remaining
+	}
+
+	for _, member := range merged {
+		ctx.record(FlattenMerged, member, "", key)
+	}
+	if lifted != "" {
+		ctx.record(FlattenLifted, key, "", lifted)
+	}
+
+	return &simplified, true
+}
+
+// mergeSchemaFields merges the JSON fields of an inline allOf member into the fields of its parent.
+//
+// It returns false when the outcome would not validate like the original composition,
+// e.g. when both schemas define the same keyword with different values.
+func mergeSchemaFields(parent, member map[string]any) (map[string]any, bool) {
+	for _, keyword := range unmergeableKeywords {
+		if _, found := member[keyword]; found {
+			return nil, false
+		}
+	}
+
+	// additionalProperties only applies to the properties declared in the same schema
+	if _, found := member["additionalProperties"]; found && declaresProperties(parent) {
+		return nil, false
+	}
+	if _, found := parent["additionalProperties"]; found && declaresProperties(member) {
+		return nil, false
+	}
+
+	merged := maps.Clone(parent)
+	for _, keyword := range sortedKeys(member) {
+		value := member[keyword]
+		existing, found := merged[keyword]
+		if !found {
+			merged[keyword] = value
+
+			continue
+		}
+
+		switch keyword {
+		case "required":
+			merged[keyword] = unionOfRequired(existing, value)
+		case "properties", "patternProperties":
+			union, ok := unionOfProperties(existing, value)
+			if !ok {
+				return nil, false
+			}
+			merged[keyword] = union
+		default:
+			if !reflect.DeepEqual(existing, value) {
+				return nil, false
+			}
+		}
+	}
+
+	return merged, true
+}
+
+func declaresProperties(fields map[string]any) bool {
+	_, hasProperties := fields["properties"]
+	_, hasPatternProperties := fields["patternProperties"]
+
+	return hasProperties || hasPatternProperties
+}
+
+func unionOfRequired(required, other any) any {
+	union, _ := required.([]any)
+	union = slices.Clone(union)
+	others, _ := other.([]any)
+	for _, name := range others {
+		if !slices.Contains(union, name) {
+			union = append(union, name)
+		}
+	}
+
+	return union
+}
+
+func unionOfProperties(properties, other any) (any, bool) {
+	union, okUnion := properties.(map[string]any)
+	others, okOthers := other.(map[string]any)
+	if !okUnion || !okOthers {
+		return nil, false
+	}
+
+	union = maps.Clone(union)
+	for name, property := range others {
+		if existing, found := union[name]; found && !reflect.DeepEqual(existing, property) {
+			return nil, false
+		}
+		union[name] = property
+	}
+
+	return union, true
+}
+
+// schemaAt retrieves the schema located by a JSON pointer in a swagger document.
+func schemaAt(sp *spec.Swagger, key string) (*spec.Schema, bool) {
+	ptr, err := jsonpointer.New(strings.TrimPrefix(key, "#"))
+	if err != nil {
+		return nil, false
+	}
+
+	value, _, err := ptr.Get(sp)
+	if err != nil {
+		return nil, false
+	}
+
+	switch sch := value.(type) {
+	case spec.Schema:
+		return &sch, true
+	case *spec.Schema:
+		return sch, true
+	default:
+		return nil, false
+	}
+}
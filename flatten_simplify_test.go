@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/analysis/internal/antest"
+	"github.com/go-openapi/spec"
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestFlatten_SimplifyAllOf(t *testing.T) {
+	t.Parallel()
+
+	bp := filepath.Join("fixtures", "allOf-simplify.yml")
+
+	t.Run("should simplify allOf compositions", func(t *testing.T) {
+		t.Parallel()
+
+		doc := antest.LoadOrFail(t, bp)
+		report, err := FlattenWithReport(FlattenOpts{Spec: New(doc), BasePath: bp, Minimal: true, SimplifyAllOf: true})
+		require.NoError(t, err)
+		definitions := doc.Definitions
+
+		t.Run("with a single $ref lifted", func(t *testing.T) {
+			assert.Equal(t, *spec.RefSchema("#/definitions/base"), definitions["wrapped"])
+			assert.Equal(t, []FlattenChange{
+				{Kind: FlattenLifted, Key: "#/definitions/wrapped", To: "#/definitions/base"},
+			}, report.ChangesAt("#/definitions/wrapped"))
+
+			response := doc.Paths.Paths["/things"].Get.Responses.StatusCodeResponses[200]
+			assert.EqualT(t, "#/definitions/base", response.Schema.Ref.String())
+			assert.Empty(t, response.Schema.AllOf)
+		})
+
+		t.Run("with validation-only and extensions-only members merged", func(t *testing.T) {
+			validated := definitions["validated"]
+			assert.Empty(t, validated.AllOf)
+			require.NotNil(t, validated.MaxProperties)
+			assert.EqualT(t, int64(5), *validated.MaxProperties)
+			assert.Equal(t, []string{"name", "other"}, validated.Required)
+			goName, ok := validated.Extensions.GetString("x-go-name")
+			assert.TrueT(t, ok)
+			assert.EqualT(t, "Renamed", goName)
+
+			for _, member := range []string{"0", "1", "2"} {
+				assert.Equal(t, []FlattenChange{
+					{Kind: FlattenMerged, Key: "#/definitions/validated/allOf/" + member, To: "#/definitions/validated"},
+				}, report.ChangesAt("#/definitions/validated/allOf/"+member))
+			}
+		})
+
+		t.Run("with single member objects and arrays merged", func(t *testing.T) {
+			single := definitions["singleObject"]
+			assert.Empty(t, single.AllOf)
+			assert.TrueT(t, single.Type.Contains("object"))
+			assert.Equal(t, []string{"a"}, single.Required)
+			assert.Contains(t, single.Properties, "a")
+
+			array := definitions["arrayWrapper"]
+			assert.Empty(t, array.AllOf)
+			assert.TrueT(t, array.Type.Contains("array"))
+			require.NotNil(t, array.Items)
+			require.NotNil(t, array.Items.Schema)
+			assert.EqualT(t, "#/definitions/base", array.Items.Schema.Ref.String())
+		})
+
+		t.Run("with inline members merged next to a $ref", func(t *testing.T) {
+			composed := definitions["composed"]
+			require.Len(t, composed.AllOf, 1)
+			assert.EqualT(t, "#/definitions/base", composed.AllOf[0].Ref.String())
+			assert.Contains(t, composed.Properties, "extra")
+			assert.Empty(t, report.ChangesAt("#/definitions/composed/allOf/0"))
+			assert.Len(t, report.ChangesAt("#/definitions/composed/allOf/1"), 1)
+		})
+
+		t.Run("with unsafe merges left untouched", func(t *testing.T) {
+			for _, name := range []string{"closed", "conflicting"} {
+				sch := definitions[name]
+				assert.Lenf(t, sch.AllOf, 1, "expected %s to keep its allOf", name)
+				assert.Lenf(t, sch.Properties, 1, "expected %s to keep its properties", name)
+			}
+		})
+
+		t.Run("with polymorphic definitions left untouched", func(t *testing.T) {
+			dog := definitions["dog"]
+			require.Len(t, dog.AllOf, 2)
+			assert.Empty(t, dog.Properties)
+
+			discriminated, ok := New(doc).DiscriminatedTypeFor("pet")
+			require.TrueT(t, ok)
+			require.Len(t, discriminated.Subtypes, 1)
+			assert.EqualT(t, "dog", discriminated.Subtypes[0].Name)
+		})
+	})
+
+	t.Run("should simplify allOf compositions before naming inline schemas", func(t *testing.T) {
+		t.Parallel()
+
+		doc := antest.LoadOrFail(t, bp)
+		require.NoError(t, Flatten(FlattenOpts{Spec: New(doc), BasePath: bp, SimplifyAllOf: true}))
+
+		assert.NotContains(t, doc.Definitions, "composedAllOf1")
+		assert.NotContains(t, doc.Definitions, "singleObjectAllOf0")
+		assert.Contains(t, doc.Definitions, "dogAllOf1")
+	})
+
+	t.Run("should leave allOf compositions alone by default", func(t *testing.T) {
+		t.Parallel()
+
+		doc := antest.LoadOrFail(t, bp)
+		report, err := FlattenWithReport(FlattenOpts{Spec: New(doc), BasePath: bp, Minimal: true})
+		require.NoError(t, err)
+
+		assert.Len(t, doc.Definitions["wrapped"].AllOf, 1)
+		assert.Len(t, doc.Definitions["validated"].AllOf, 3)
+		for _, change := range report.Changes {
+			assert.NotEqualT(t, FlattenLifted, change.Kind)
+			assert.NotEqualT(t, FlattenMerged, change.Kind)
+		}
+	})
+}
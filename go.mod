@@ -8,6 +8,7 @@ require (
 	github.com/go-openapi/swag/loading v0.27.3
 	github.com/go-openapi/swag/mangling v0.27.3
 	github.com/go-openapi/testify/v2 v2.6.0
+	go.yaml.in/yaml/v3 v3.0.4
 	golang.org/x/text v0.40.0
 )
 
@@ -22,7 +23,6 @@ require (
 	github.com/go-viper/mapstructure/v2 v2.5.0 // indirect
 	github.com/google/uuid v1.6.0 // indirect
 	github.com/oklog/ulid/v2 v2.1.1 // indirect
-	go.yaml.in/yaml/v3 v3.0.4 // indirect
 	golang.org/x/net v0.57.0 // indirect
 )
 
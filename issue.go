@@ -0,0 +1,254 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	slashpath "path"
+	"slices"
+	"strings"
+
+	"github.com/go-openapi/jsonpointer"
+	"github.com/go-openapi/spec"
+)
+
+// IssueSeverity tells how serious an [Issue] is.
+type IssueSeverity string
+
+const (
+	// SeverityError denotes an invalid construct.
+	SeverityError IssueSeverity = "error"
+
+	// SeverityWarning denotes a valid, but possibly unwanted construct.
+	SeverityWarning IssueSeverity = "warning"
+
+	// SeverityInfo denotes a notification, e.g. about some change applied to the spec.
+	SeverityInfo IssueSeverity = "info"
+)
+
+// IssueCode identifies the kind of an [Issue], in the form "{family}/{kind}", e.g. "pattern/invalid".
+//
+// Codes derived from the kinds of issues reported by the checks of the analyzer (e.g. [PatternIssueKind])
+// use these kinds verbatim.
+type IssueCode string
+
+const (
+	// IssueInvalidRef reports a $ref which does not resolve.
+	IssueInvalidRef IssueCode = "ref/invalid"
+
+	// IssuePathParamMissing reports a parameter of a path template which is not declared by an operation.
+	IssuePathParamMissing IssueCode = "path-param/missing"
+
+	// IssuePathParamNotInTemplate reports an "in: path" parameter which is not part of the path template.
+	IssuePathParamNotInTemplate IssueCode = "path-param/not-in-template"
+
+	// IssueParamDuplicate reports parameters declared more than once at the same level.
+	IssueParamDuplicate IssueCode = "param/duplicate"
+
+	// IssueParamIncompatibleOverride reports a path-level parameter overridden with another type.
+	IssueParamIncompatibleOverride IssueCode = "param/incompatible-override"
+
+	// IssueParamMultipleBody reports an operation with several body parameters.
+	IssueParamMultipleBody IssueCode = "param/multiple-body"
+
+	// IssueParamBodyWithFormData reports an operation with both body and formData parameters.
+	IssueParamBodyWithFormData IssueCode = "param/body-with-form-data"
+
+	// IssueFlattenNameConflict reports a definition created by flatten with a name deduplicated
+	// with a generated suffix.
+	IssueFlattenNameConflict IssueCode = "flatten/name-conflict"
+
+	// IssueFlattenRefAsSchema reports a $ref to some non-schema construct, interpreted as a schema by flatten.
+	IssueFlattenRefAsSchema IssueCode = "flatten/ref-as-schema"
+)
+
+// Issue is a diagnostic about the construct located at Pointer.
+//
+// Issues are suitable to annotate the source of a spec, e.g. in a CI pipeline.
+type Issue struct {
+	Severity IssueSeverity `json:"severity"`
+	Code     IssueCode     `json:"code"`
+
+	// Pointer is the JSON pointer to the construct, e.g. "#/definitions/Pet".
+	Pointer string `json:"pointer"`
+
+	// File is the location of the document the spec is loaded from, when known.
+	File string `json:"file,omitempty"`
+
+	// Line and Column locate the construct in File, when known (both start at 1).
+	Line   int `json:"line,omitempty"`
+	Column int `json:"column,omitempty"`
+
+	Message string `json:"message"`
+}
+
+func (i Issue) String() string {
+	var location strings.Builder
+	if i.File != "" {
+		location.WriteString(i.File)
+		if i.Line > 0 {
+			fmt.Fprintf(&location, ":%d:%d", i.Line, i.Column)
+		}
+		location.WriteString(": ")
+	}
+
+	return fmt.Sprintf("%s%s: %s [%s] %s", location.String(), i.Severity, i.Pointer, i.Code, i.Message)
+}
+
+// Issue converts a pattern issue into an [Issue].
+func (p PatternIssue) Issue() Issue {
+	severity := SeverityError
+	if p.Kind == PatternNotECMA || p.Kind == PatternCatastrophic {
+		severity = SeverityWarning
+	}
+
+	return Issue{Severity: severity, Code: IssueCode("pattern/" + p.Kind), Pointer: p.Key, Message: p.Message}
+}
+
+// Issue converts an enum issue into an [Issue].
+func (e EnumIssue) Issue() Issue {
+	severity := SeverityWarning
+	if e.Kind == EnumTypeMismatch || e.Kind == EnumDefaultNotInEnum {
+		severity = SeverityError
+	}
+
+	return Issue{Severity: severity, Code: IssueCode("enum/" + e.Kind), Pointer: e.Key, Message: e.Message}
+}
+
+// Issue converts a discriminator issue into an [Issue].
+func (d DiscriminatorIssue) Issue() Issue {
+	return Issue{Severity: SeverityError, Code: IssueCode("discriminator/" + d.Kind), Pointer: d.Key, Message: d.Message}
+}
+
+// Issue converts a path parameter mismatch into an [Issue].
+func (m PathParamMismatch) Issue() Issue {
+	issue := Issue{Severity: SeverityError, Pointer: operationPointer(m.Method, m.Path)}
+	if m.InTemplate {
+		issue.Code = IssuePathParamMissing
+		issue.Message = fmt.Sprintf("path parameter %q is not declared by the operation", m.Name)
+	} else {
+		issue.Code = IssuePathParamNotInTemplate
+		issue.Message = fmt.Sprintf("path parameter %q is not part of the path template", m.Name)
+	}
+
+	return issue
+}
+
+// Issue converts a route conflict into an [Issue].
+func (c RouteConflict) Issue() Issue {
+	severity := SeverityWarning
+	if c.Kind == RouteEquivalent {
+		severity = SeverityError
+	}
+
+	return Issue{
+		Severity: severity,
+		Code:     IssueCode("route/" + c.Kind),
+		Pointer:  "#" + slashpath.Join("/paths", jsonpointer.Escape(c.Path)),
+		Message:  fmt.Sprintf("path %q conflicts with %q (%s)", c.Path, c.Other, c.Kind),
+	}
+}
+
+// Issues runs all the checks of the analyzer and reports their findings as [Issue]s:
+//
+//   - [Spec.CheckPatterns], [Spec.CheckEnums] and [Spec.CheckDiscriminators]
+//   - [Spec.PathParamMismatches] and [Spec.RouteConflicts]
+//   - parameters which fail to resolve, and parameter diagnostics when enabled with [WithParamDiagnostics]
+//
+// Issues are located in the source documents of the spec when analyzed with [WithPositions].
+//
+// Issues are sorted by pointer, then by code.
+func (s *Spec) Issues() []Issue {
+	var issues []Issue
+	for _, issue := range s.CheckPatterns() {
+		issues = append(issues, issue.Issue())
+	}
+
+	for _, issue := range s.CheckEnums() {
+		issues = append(issues, issue.Issue())
+	}
+
+	for _, issue := range s.CheckDiscriminators() {
+		issues = append(issues, issue.Issue())
+	}
+
+	for _, mismatch := range s.PathParamMismatches() {
+		issues = append(issues, mismatch.Issue())
+	}
+
+	for _, conflict := range s.RouteConflicts() {
+		issues = append(issues, conflict.Issue())
+	}
+
+	for _, path := range sortedKeys(s.AllPaths()) {
+		for _, method := range s.methodsFor(path) {
+			pointer := operationPointer(method, path)
+			_ = s.SafeParamsFor(method, path, func(_ spec.Parameter, err error) bool {
+				issues = append(issues, Issue{
+					Severity: issueSeverity(err),
+					Code:     issueCode(err),
+					Pointer:  pointer,
+					Message:  issueMessage(err),
+				})
+
+				return true
+			})
+		}
+	}
+
+	sortIssues(issues)
+	locateIssues(issues, s.positions)
+
+	return issues
+}
+
+// codedError attaches an [IssueCode] and a plain message to an error.
+type codedError struct {
+	error
+
+	code    IssueCode
+	message string
+}
+
+func (e codedError) Unwrap() error {
+	return e.error
+}
+
+func issueCode(err error) IssueCode {
+	var coded codedError
+	if errors.As(err, &coded) {
+		return coded.code
+	}
+
+	return IssueInvalidRef
+}
+
+func issueMessage(err error) string {
+	var coded codedError
+	if errors.As(err, &coded) {
+		return coded.message
+	}
+
+	return err.Error()
+}
+
+func issueSeverity(err error) IssueSeverity {
+	if issueCode(err) == IssueParamIncompatibleOverride {
+		return SeverityWarning
+	}
+
+	return SeverityError
+}
+
+func sortIssues(issues []Issue) {
+	slices.SortStableFunc(issues, func(a, b Issue) int {
+		return cmp.Or(strings.Compare(a.Pointer, b.Pointer), strings.Compare(string(a.Code), string(b.Code)))
+	})
+}
+
+func operationPointer(method, path string) string {
+	return "#" + slashpath.Join("/paths", jsonpointer.Escape(path), strings.ToLower(method))
+}
@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/analysis/internal/antest"
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestIssues(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should report parameter diagnostics", func(t *testing.T) {
+		t.Parallel()
+
+		doc := antest.LoadOrFail(t, filepath.Join("fixtures", "param-diagnostics.yml"))
+		issues := New(doc, WithParamDiagnostics()).Issues()
+
+		require.Len(t, issues, 5)
+		assert.Equal(t, Issue{
+			Severity: SeverityError,
+			Code:     IssueParamDuplicate,
+			Pointer:  "#/paths/~1items~1{id}/get",
+			Message:  `duplicate parameter "query#Limit"`,
+		}, issues[0])
+		assert.EqualT(t, IssueParamIncompatibleOverride, issues[1].Code)
+		assert.EqualT(t, SeverityWarning, issues[1].Severity)
+		assert.EqualT(t, IssueParamBodyWithFormData, issues[3].Code)
+		assert.EqualT(t, IssueParamMultipleBody, issues[4].Code)
+		assert.EqualT(t, "#/paths/~1items~1{id}/put", issues[4].Pointer)
+
+		assert.Empty(t, New(doc).Issues())
+	})
+
+	t.Run("should report route issues", func(t *testing.T) {
+		t.Parallel()
+
+		doc := antest.LoadOrFail(t, filepath.Join("fixtures", "routes.yml"))
+		issues := New(doc).Issues()
+
+		require.Len(t, issues, 6)
+		assert.Equal(t, Issue{
+			Severity: SeverityError,
+			Code:     "route/equivalent",
+			Pointer:  "#/paths/~1pets~1{id}",
+			Message:  `path "/pets/{id}" conflicts with "/pets/{name}" (equivalent)`,
+		}, issues[2])
+		assert.EqualT(t, IssuePathParamMissing, issues[3].Code)
+		assert.EqualT(t, IssuePathParamNotInTemplate, issues[4].Code)
+		assert.EqualT(t, `error: #/paths/~1stores~1{store}~1items/post [path-param/missing] path parameter "store" is not declared by the operation`,
+			issues[5].String())
+	})
+
+	t.Run("should convert checks", func(t *testing.T) {
+		t.Parallel()
+
+		pattern := PatternIssue{Key: "#/definitions/a", Kind: PatternCatastrophic, Message: "nested"}.Issue()
+		assert.EqualT(t, SeverityWarning, pattern.Severity)
+		assert.EqualT(t, IssueCode("pattern/catastrophic-backtracking"), pattern.Code)
+
+		enum := EnumIssue{Key: "#/definitions/b", Kind: EnumDefaultNotInEnum, Message: "default"}.Issue()
+		assert.EqualT(t, SeverityError, enum.Severity)
+		assert.EqualT(t, IssueCode("enum/default-not-in-enum"), enum.Code)
+
+		discriminator := DiscriminatorIssue{Key: "#/definitions/c", Kind: DiscriminatorNotRequired}.Issue()
+		assert.EqualT(t, IssueCode("discriminator/not-required"), discriminator.Code)
+		assert.EqualT(t, "#/definitions/c", discriminator.Pointer)
+	})
+}
+
+func TestFlattenWithIssues(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should report $ref interpreted as schema", func(t *testing.T) {
+		t.Parallel()
+
+		bp := filepath.Join("fixtures", "flatten-issues.yml")
+		doc := antest.LoadOrFail(t, bp)
+
+		issues, err := FlattenWithIssues(FlattenOpts{Spec: New(doc), BasePath: bp, Minimal: true})
+		require.NoError(t, err)
+		require.Len(t, issues, 1)
+		assert.Equal(t, Issue{
+			Severity: SeverityWarning,
+			Code:     IssueFlattenRefAsSchema,
+			Pointer:  "#/definitions/item/properties/error",
+			Message:  `found $ref "#/responses/error" (response) interpreted as schema`,
+		}, issues[0])
+	})
+
+	t.Run("should report name conflicts", func(t *testing.T) {
+		t.Parallel()
+
+		bp := filepath.Join("fixtures", "oaigen", "fixture-oaigen.yaml")
+		doc := antest.LoadOrFail(t, bp)
+
+		issues, err := FlattenWithIssues(FlattenOpts{Spec: New(doc), BasePath: bp, Positions: loadPositions(t, bp)})
+		require.NoError(t, err)
+		require.Len(t, issues, 1)
+		assert.EqualT(t, IssueFlattenNameConflict, issues[0].Code)
+		assert.EqualT(t, "#/definitions/aAOAIGen", issues[0].Pointer)
+
+		// the definition is created by flatten: it is located at the enclosing definitions
+		expected, ok := loadPositions(t, bp).Position("#/definitions")
+		require.TrueT(t, ok)
+		assert.EqualT(t, expected.Line, issues[0].Line)
+		assert.NotEmpty(t, issues[0].File)
+	})
+
+	t.Run("should not flatten a snapshot", func(t *testing.T) {
+		t.Parallel()
+
+		doc := antest.LoadOrFail(t, filepath.Join("fixtures", "flatten-issues.yml"))
+		snapshot := New(doc).Snapshot()
+
+		_, err := FlattenWithIssues(FlattenOpts{Spec: snapshot.Spec})
+		require.ErrorIs(t, err, ErrImmutableSpec)
+	})
+}
@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// Metrics summarizes the size and complexity of a spec.
+//
+// Metrics serialize to JSON, e.g. to feed some dashboard.
+type Metrics struct {
+	Operations  int `json:"operations"`
+	Definitions int `json:"definitions"`
+
+	// InlineSchemas counts the schemas which are neither a top-level definition nor a $ref.
+	InlineSchemas int `json:"inlineSchemas"`
+
+	Refs       int `json:"refs"`
+	RemoteRefs int `json:"remoteRefs"`
+	Enums      int `json:"enums"`
+	Patterns   int `json:"patterns"`
+
+	// AllOfs counts the schemas with an allOf composition.
+	AllOfs int `json:"allOfs"`
+
+	// MaxSchemaDepth is the maximum nesting level of schemas, $ref not being followed.
+	// A top-level definition with properties has depth 2.
+	MaxSchemaDepth int `json:"maxSchemaDepth"`
+
+	// MaxRefChain is the maximum number of $ref to follow before resolving a schema,
+	// e.g. when a definition is only a $ref to another definition.
+	MaxRefChain int `json:"maxRefChain"`
+
+	// OperationParameters lists operations with their count of effective parameters,
+	// in the order of [Spec.OperationViews].
+	OperationParameters []OperationMetrics `json:"operationParameters"`
+}
+
+// OperationMetrics counts the parameters of an operation.
+type OperationMetrics struct {
+	Method     string `json:"method"`
+	Path       string `json:"path"`
+	ID         string `json:"id,omitempty"`
+	Parameters int    `json:"parameters"`
+}
+
+// Metrics computes size and complexity metrics from the indices of the analyzed spec.
+//
+// Parameters which fail to resolve are not counted.
+func (s *Spec) Metrics() Metrics {
+	metrics := Metrics{
+		Definitions:         len(s.spec.Definitions),
+		Refs:                len(s.references.allRefs),
+		Enums:               len(s.enums.allEnums),
+		Patterns:            len(s.patterns.allPatterns),
+		AllOfs:              len(s.allOfs),
+		OperationParameters: make([]OperationMetrics, 0, allocMediumMap),
+	}
+
+	for view := range s.SafeOperationViews(func(spec.Parameter, error) bool { return true }) {
+		metrics.Operations++
+		metrics.OperationParameters = append(metrics.OperationParameters, OperationMetrics{
+			Method:     view.Method,
+			Path:       view.Path,
+			ID:         view.ID,
+			Parameters: len(view.Parameters),
+		})
+	}
+
+	depths := make(map[string]int, len(s.allSchemas))
+	for key, schRef := range s.allSchemas {
+		if !schRef.TopLevel && schRef.Schema != nil && schRef.Schema.Ref.String() == "" {
+			metrics.InlineSchemas++
+		}
+
+		metrics.MaxSchemaDepth = max(metrics.MaxSchemaDepth, s.schemaDepth(key, depths))
+	}
+
+	for key, ref := range s.references.allRefs {
+		if !ref.HasFragmentOnly {
+			metrics.RemoteRefs++
+
+			continue
+		}
+
+		metrics.MaxRefChain = max(metrics.MaxRefChain, s.refChainLength(key))
+	}
+
+	return metrics
+}
+
+// schemaDepth yields the nesting level of the schema at key, i.e. 1 + the depth of the closest enclosing schema.
+func (s *Spec) schemaDepth(key string, depths map[string]int) int {
+	if depth, ok := depths[key]; ok {
+		return depth
+	}
+
+	depth := 1
+	for parent := key; ; {
+		index := strings.LastIndexByte(parent, '/')
+		if index <= 0 {
+			break
+		}
+
+		parent = parent[:index]
+		if _, ok := s.allSchemas[parent]; ok {
+			depth += s.schemaDepth(parent, depths)
+
+			break
+		}
+	}
+	depths[key] = depth
+
+	return depth
+}
+
+// refChainLength counts the $ref to follow from key until reaching some location which is not a local $ref.
+//
+// Cyclical chains stop when some location is visited again.
+func (s *Spec) refChainLength(key string) int {
+	visited := make(map[string]struct{}, allocSmallMap)
+	length := 0
+	for {
+		ref, ok := s.references.allRefs[key]
+		if !ok || !ref.HasFragmentOnly {
+			return length
+		}
+
+		if _, cyclic := visited[key]; cyclic {
+			return length
+		}
+		visited[key] = struct{}{}
+
+		length++
+		key = ref.String()
+	}
+}
@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/analysis/internal/antest"
+	"github.com/go-openapi/spec"
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestAnalyzer_Metrics(t *testing.T) {
+	t.Parallel()
+
+	doc := antest.LoadOrFail(t, filepath.Join("fixtures", "usage.yml"))
+	doc.Definitions["alias"] = *spec.RefSchema("#/definitions/pet")
+	doc.Definitions["aliasOfAlias"] = *spec.RefSchema("#/definitions/alias")
+	doc.Definitions["remote"] = *spec.RefSchema("other.yml#/definitions/thing")
+	metrics := New(doc).Metrics()
+
+	assert.Equal(t, Metrics{
+		Operations:     3,
+		Definitions:    10,
+		InlineSchemas:  8,
+		Refs:           14,
+		RemoteRefs:     1,
+		AllOfs:         2,
+		MaxSchemaDepth: 4,
+		MaxRefChain:    2,
+		OperationParameters: []OperationMetrics{
+			{Method: "GET", Path: "/pets", ID: "listPets", Parameters: 0},
+			{Method: "POST", Path: "/pets", ID: "createPet", Parameters: 1},
+			{Method: "PUT", Path: "/pets/{id}", ID: "updatePet", Parameters: 2},
+		},
+	}, metrics)
+
+	t.Run("should count enums and patterns", func(t *testing.T) {
+		metrics := New(antest.LoadOrFail(t, filepath.Join("fixtures", "enums.yml"))).Metrics()
+		assert.Equal(t, 14, metrics.Enums)
+
+		metrics = New(antest.LoadOrFail(t, filepath.Join("fixtures", "patterns.yml"))).Metrics()
+		assert.Positive(t, metrics.Patterns)
+	})
+
+	t.Run("should serialize to JSON", func(t *testing.T) {
+		buf, err := json.Marshal(metrics)
+		require.NoError(t, err)
+
+		var decoded Metrics
+		require.NoError(t, json.Unmarshal(buf, &decoded))
+		assert.Equal(t, metrics, decoded)
+		assert.Contains(t, string(buf), `"maxRefChain":2`)
+		assert.Contains(t, string(buf), `{"method":"GET","path":"/pets","id":"listPets","parameters":0}`)
+	})
+
+	t.Run("should stop on cyclical $ref", func(t *testing.T) {
+		doc := antest.LoadOrFail(t, filepath.Join("fixtures", "usage.yml"))
+		doc.Definitions["a"] = *spec.RefSchema("#/definitions/b")
+		doc.Definitions["b"] = *spec.RefSchema("#/definitions/a")
+
+		assert.Equal(t, 2, New(doc).Metrics().MaxRefChain)
+	})
+}
@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+
+	"github.com/go-openapi/spec"
+)
+
+// OperationView is a resolved view of an operation, with all the properties inherited
+// from the path item and from the spec already applied.
+type OperationView struct {
+	Method    string          // HTTP method, in upper case
+	Path      string          // path template, e.g. "/pets/{id}"
+	ID        string          // operationId, possibly empty
+	Operation *spec.Operation // the operation in the spec document
+
+	// Parameters merges path item parameters with operation parameters, with $ref resolved.
+	// Operation parameters override path item parameters with the same location and name.
+	//
+	// Parameters are sorted by location, then by name.
+	Parameters []spec.Parameter
+
+	Consumes []string                // effective consumed media types, sorted
+	Produces []string                // effective produced media types, sorted
+	Security [][]SecurityRequirement // effective security requirements, each alternative sorted by name
+	Tags     []string
+}
+
+//nolint:gochecknoglobals // it's okay to store small indexes like this as private globals
+var operationMethodsOrder = []string{"GET", "PUT", "POST", "PATCH", "DELETE", "HEAD", "OPTIONS"}
+
+// OperationViews iterates over the resolved views of all the operations in the spec.
+//
+// Operations are yielded in lexicographic order of their path, then for a given path in the
+// following order of methods: GET, PUT, POST, PATCH, DELETE, HEAD, OPTIONS.
+//
+// Assumes parameters properly resolve references if any and that
+// such references actually resolve to a parameter object.
+// Otherwise, panics.
+func (s *Spec) OperationViews() iter.Seq[OperationView] {
+	return s.SafeOperationViews(nil)
+}
+
+// SafeOperationViews iterates over the resolved views of all the operations in the spec,
+// like [Spec.OperationViews].
+//
+// Does not assume parameters properly resolve references or that
+// such references actually resolve to a parameter object.
+//
+// Upon error, invoke a [ErrorOnParamFunc] callback with the erroneous
+// parameters. If the callback is set to nil, panics upon errors.
+func (s *Spec) SafeOperationViews(callmeOnError ErrorOnParamFunc) iter.Seq[OperationView] {
+	return func(yield func(OperationView) bool) {
+		paths := make(map[string]struct{}, allocMediumMap)
+		for _, pathItem := range s.operations {
+			for path := range pathItem {
+				paths[path] = struct{}{}
+			}
+		}
+
+		for _, path := range s.sortedStructMapKeys(paths) {
+			for _, method := range operationMethodsOrder {
+				op, ok := s.operations[method][path]
+				if !ok || op == nil {
+					continue
+				}
+
+				if !yield(s.operationView(method, path, op, callmeOnError)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *Spec) operationView(method, path string, op *spec.Operation, callmeOnError ErrorOnParamFunc) OperationView {
+	params := s.SafeParamsFor(method, path, callmeOnError)
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	parameters := make([]spec.Parameter, 0, len(keys))
+	for _, k := range keys {
+		parameters = append(parameters, params[k])
+	}
+
+	consumes := s.ConsumesFor(op)
+	slices.Sort(consumes)
+	produces := s.ProducesFor(op)
+	slices.Sort(produces)
+
+	security := s.SecurityRequirementsFor(op)
+	for _, requirements := range security {
+		slices.SortFunc(requirements, func(a, b SecurityRequirement) int {
+			return cmp.Compare(a.Name, b.Name)
+		})
+	}
+
+	return OperationView{
+		Method:     method,
+		Path:       path,
+		ID:         op.ID,
+		Operation:  op,
+		Parameters: parameters,
+		Consumes:   consumes,
+		Produces:   produces,
+		Security:   security,
+		Tags:       slices.Clone(op.Tags),
+	}
+}
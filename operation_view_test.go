@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestAnalyzer_OperationViews(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should iterate in path, then method order", func(t *testing.T) {
+		an := prepareTestParamsAuth()
+		pi := an.spec.Paths.Paths["/items"]
+		pi.Delete = spec.NewOperation("deleteItems").WithTags("items", "admin")
+		pi.Post = spec.NewOperation("createItems").WithConsumes("application/x-yaml", "application/x-protobuf")
+		an.spec.Paths.Paths["/items"] = pi
+		an.reload()
+
+		views := make([]OperationView, 0, 4)
+		for view := range an.OperationViews() {
+			views = append(views, view)
+		}
+		require.Len(t, views, 4)
+
+		ids := make([]string, 0, len(views))
+		for _, view := range views {
+			ids = append(ids, view.Method+" "+view.Path+" "+view.ID)
+		}
+		assert.Equal(t, []string{
+			"GET / " + someOperation,
+			"GET /items " + anotherOperation,
+			"POST /items createItems",
+			"DELETE /items deleteItems",
+		}, ids)
+
+		first := views[0]
+		assert.Equal(t, an.spec.Paths.Paths["/"].Get, first.Operation)
+		assert.Equal(t, []string{"application/x-yaml"}, first.Consumes)
+		assert.Equal(t, []string{"application/x-yaml"}, first.Produces)
+		require.Len(t, first.Parameters, 2)
+		assert.Equal(t, "limit", first.Parameters[0].Name)
+		assert.Equal(t, "skip", first.Parameters[1].Name)
+		assert.Equal(t, [][]SecurityRequirement{
+			{{Name: "oauth2", Scopes: []string{"the-scope"}}},
+			{{Name: "basic", Scopes: []string{}}},
+		}, first.Security)
+
+		second := views[1]
+		assert.Equal(t, [][]SecurityRequirement{
+			{{Name: "oauth2", Scopes: []string{"the-scope"}}},
+			{{}},
+			{{Name: "apiKey", Scopes: []string{}}, {Name: "basic", Scopes: []string{}}},
+		}, second.Security)
+
+		third := views[2]
+		assert.Equal(t, []string{"application/x-protobuf", "application/x-yaml"}, third.Consumes)
+		assert.Equal(t, []string{"application/json"}, third.Produces)
+		assert.Equal(t, [][]SecurityRequirement{{{Name: "apikey", Scopes: []string{}}}}, third.Security)
+
+		assert.Equal(t, []string{"items", "admin"}, views[3].Tags)
+	})
+
+	t.Run("should stop iterating", func(t *testing.T) {
+		an := prepareTestParamsAuth()
+
+		var count int
+		for range an.OperationViews() {
+			count++
+
+			break
+		}
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("should report invalid parameters", func(t *testing.T) {
+		an := prepareTestParamsInvalid(t, "fixture-342.yaml")
+
+		var errs int
+		for view := range an.SafeOperationViews(func(_ spec.Parameter, _ error) bool {
+			errs++
+
+			return true
+		}) {
+			assert.NotEmpty(t, view.Method)
+		}
+		assert.Positive(t, errs)
+
+		assert.Panics(t, func() {
+			for range an.OperationViews() {
+			}
+		})
+	})
+}
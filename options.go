@@ -9,7 +9,9 @@ import "github.com/go-openapi/swag/mangling"
 type Option func(*analyzerOptions)
 
 type analyzerOptions struct {
-	manglerOpts []mangling.Option
+	manglerOpts      []mangling.Option
+	paramDiagnostics bool
+	positions        *PositionMap
 }
 
 // WithManglerOptions sets the name mangler options used when building
@@ -19,3 +21,30 @@ func WithManglerOptions(opts ...mangling.Option) Option {
 		o.manglerOpts = append(o.manglerOpts, opts...)
 	}
 }
+
+// WithParamDiagnostics enables diagnostics when gathering the parameters of an operation,
+// e.g. with [Spec.SafeParamsFor] or [Spec.SafeParametersFor].
+//
+// Diagnostics are reported to the [ErrorOnParamFunc] callback as errors wrapping [ErrParamDiagnostic], for:
+//
+//   - duplicate parameters declared at the same level
+//   - path-level parameters overridden at the operation level with an incompatible type
+//   - multiple body parameters
+//   - body parameters combined with formData parameters
+//
+// Diagnostics are advisory: when the callback is nil (e.g. with [Spec.ParamsFor]), they are ignored,
+// whereas parameters which fail to resolve still cause a panic.
+func WithParamDiagnostics() Option {
+	return func(o *analyzerOptions) {
+		o.paramDiagnostics = true
+	}
+}
+
+// WithPositions associates the analyzed spec with the positions of its constructs in the source documents.
+//
+// Positions are reported with [Issue]s and may be looked up with [Spec.PositionOf].
+func WithPositions(positions *PositionMap) Option {
+	return func(o *analyzerOptions) {
+		o.positions = positions
+	}
+}
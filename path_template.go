@@ -0,0 +1,263 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// PathSegment is a segment of a path template, e.g. "pets", "{id}" or "{name}.{ext}".
+type PathSegment struct {
+	// Value of the segment, as declared in the path template.
+	Value string
+
+	// Params holds the names of the path parameters declared in this segment, if any.
+	Params []string
+}
+
+// IsParam is true when the segment consists of a single path parameter, e.g. "{id}".
+func (p PathSegment) IsParam() bool {
+	return len(p.Params) == 1 && p.Value == "{"+p.Params[0]+"}"
+}
+
+// IsLiteral is true when the segment declares no path parameter.
+func (p PathSegment) IsLiteral() bool {
+	return len(p.Params) == 0
+}
+
+// shape is the segment with path parameter names removed, e.g. "{}.{}".
+func (p PathSegment) shape() string {
+	if p.IsLiteral() {
+		return p.Value
+	}
+
+	shape := p.Value
+	for _, param := range p.Params {
+		shape = strings.Replace(shape, "{"+param+"}", "{}", 1)
+	}
+
+	return shape
+}
+
+// matches is true when a literal segment is matched by this segment, e.g. "x.y" by "{a}.{b}".
+//
+// Path parameters match any non-empty value.
+func (p PathSegment) matches(literal string) bool {
+	pattern := strings.ReplaceAll(regexp.QuoteMeta(p.shape()), regexp.QuoteMeta("{}"), "(.+)")
+
+	return regexp.MustCompile("^" + pattern + "$").MatchString(literal)
+}
+
+// PathTemplate is a parsed path template, e.g. "/pets/{id}".
+type PathTemplate struct {
+	Path     string
+	Segments []PathSegment
+}
+
+// ParsePathTemplate splits a path template into segments and extracts the names of path parameters.
+//
+// Empty segments (e.g. from a trailing slash) are ignored.
+func ParsePathTemplate(path string) PathTemplate {
+	template := PathTemplate{Path: path}
+	for value := range strings.SplitSeq(path, "/") {
+		if value == "" {
+			continue
+		}
+
+		segment := PathSegment{Value: value}
+		for rest := value; ; {
+			start := strings.IndexByte(rest, '{')
+			if start < 0 {
+				break
+			}
+
+			end := strings.IndexByte(rest[start:], '}')
+			if end < 0 {
+				break
+			}
+
+			segment.Params = append(segment.Params, rest[start+1:start+end])
+			rest = rest[start+end+1:]
+		}
+
+		template.Segments = append(template.Segments, segment)
+	}
+
+	return template
+}
+
+// Params returns the names of all path parameters in the template, in order of appearance.
+func (p PathTemplate) Params() []string {
+	params := make([]string, 0, len(p.Segments))
+	for _, segment := range p.Segments {
+		params = append(params, segment.Params...)
+	}
+
+	return params
+}
+
+// RouteConflictKind qualifies a conflict between two routes.
+type RouteConflictKind string
+
+const (
+	// RouteEquivalent denotes routes matching exactly the same requests, e.g. "/pets/{id}" and "/pets/{name}".
+	RouteEquivalent RouteConflictKind = "equivalent"
+
+	// RouteShadowing denotes routes such that some requests match both, e.g. "/pets/mine" and "/pets/{id}".
+	RouteShadowing RouteConflictKind = "shadowing"
+)
+
+// RouteConflict reports two paths which routers may not tell apart.
+type RouteConflict struct {
+	Kind  RouteConflictKind
+	Path  string
+	Other string
+
+	// Methods declared on both paths, in the order of [Spec.OperationViews].
+	Methods []string
+}
+
+// PathParamMismatch reports a path parameter which is declared in the path template but not by
+// the operation (or vice versa).
+type PathParamMismatch struct {
+	Method string
+	Path   string
+	Name   string
+
+	// InTemplate is true when the parameter is declared by the path template, but is missing from the
+	// parameters of the operation. It is false when an "in: path" parameter is not declared in the template.
+	InTemplate bool
+}
+
+// PathTemplates returns the parsed templates of all paths in the spec, sorted by path.
+func (s *Spec) PathTemplates() []PathTemplate {
+	paths := sortedKeys(s.AllPaths())
+	templates := make([]PathTemplate, 0, len(paths))
+	for _, path := range paths {
+		templates = append(templates, ParsePathTemplate(path))
+	}
+
+	return templates
+}
+
+// PathParamMismatches checks that every parameter of a path template has a matching "in: path"
+// parameter in each operation of this path, and vice versa.
+//
+// Parameters are resolved like with [Spec.ParamsFor]. Parameters which fail to resolve are ignored.
+//
+// Mismatches are sorted by path, then by method in the order of [Spec.OperationViews]. For each operation,
+// parameters missing from the operation come first, then parameters missing from the template, each sorted by name.
+func (s *Spec) PathParamMismatches() []PathParamMismatch {
+	var mismatches []PathParamMismatch
+	ignoreErrors := func(spec.Parameter, error) bool { return true }
+
+	for _, template := range s.PathTemplates() {
+		inTemplate := template.Params()
+		for _, method := range s.methodsFor(template.Path) {
+			declared := make([]string, 0, len(inTemplate))
+			for _, param := range s.SafeParamsFor(method, template.Path, ignoreErrors) {
+				if param.In == "path" {
+					declared = append(declared, param.Name)
+				}
+			}
+			slices.Sort(declared)
+
+			missing := slices.Clone(inTemplate)
+			slices.Sort(missing)
+			for _, name := range slices.Compact(missing) {
+				if !slices.Contains(declared, name) {
+					mismatches = append(mismatches, PathParamMismatch{Method: method, Path: template.Path, Name: name, InTemplate: true})
+				}
+			}
+
+			for _, name := range declared {
+				if !slices.Contains(inTemplate, name) {
+					mismatches = append(mismatches, PathParamMismatch{Method: method, Path: template.Path, Name: name})
+				}
+			}
+		}
+	}
+
+	return mismatches
+}
+
+// RouteConflicts reports pairs of paths which routers may treat ambiguously.
+//
+// Paths are [RouteEquivalent] when they only differ by the names of their path parameters.
+// A path is [RouteShadowing] another one when they differ by some segments, each being matched
+// in one path by a path parameter, or by a segment with path parameters (e.g. "x.y" by "{name}.{ext}"),
+// and being a literal in the other path.
+//
+// Segments with path parameters but different shapes, e.g. "{a}.{b}" and "{a}-{b}", are not considered
+// as conflicting, although some requests may match both.
+//
+// Pairs are reported once, with Path < Other, sorted by path.
+func (s *Spec) RouteConflicts() []RouteConflict {
+	var conflicts []RouteConflict
+	templates := s.PathTemplates()
+
+	for i, template := range templates {
+		for _, other := range templates[i+1:] {
+			kind, ok := compareRoutes(template, other)
+			if !ok {
+				continue
+			}
+
+			methods := s.methodsFor(template.Path)
+			otherMethods := s.methodsFor(other.Path)
+			methods = slices.DeleteFunc(methods, func(method string) bool {
+				return !slices.Contains(otherMethods, method)
+			})
+
+			conflicts = append(conflicts, RouteConflict{Kind: kind, Path: template.Path, Other: other.Path, Methods: methods})
+		}
+	}
+
+	return conflicts
+}
+
+// compareRoutes determines if two path templates conflict.
+func compareRoutes(a, b PathTemplate) (RouteConflictKind, bool) {
+	if len(a.Segments) != len(b.Segments) {
+		return "", false
+	}
+
+	kind := RouteEquivalent
+	for i, segment := range a.Segments {
+		other := b.Segments[i]
+
+		switch {
+		case segment.shape() == other.shape():
+			continue
+		case segment.IsParam() && !other.IsParam(), other.IsParam() && !segment.IsParam():
+			kind = RouteShadowing
+		case segment.IsLiteral() && other.matches(segment.Value), other.IsLiteral() && segment.matches(other.Value):
+			kind = RouteShadowing
+		default:
+			return "", false
+		}
+	}
+
+	if kind == RouteEquivalent && a.Path == b.Path {
+		return "", false
+	}
+
+	return kind, true
+}
+
+// methodsFor returns the methods declared for a path, in the order of [Spec.OperationViews].
+func (s *Spec) methodsFor(path string) []string {
+	methods := make([]string, 0, len(operationMethodsOrder))
+	for _, method := range operationMethodsOrder {
+		if op, ok := s.operations[method][path]; ok && op != nil {
+			methods = append(methods, method)
+		}
+	}
+
+	return methods
+}
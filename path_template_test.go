@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/analysis/internal/antest"
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestParsePathTemplate(t *testing.T) {
+	t.Parallel()
+
+	template := ParsePathTemplate("/pets/{id}/photos/{photo}.{ext}/")
+	require.Len(t, template.Segments, 4)
+	assert.True(t, template.Segments[0].IsLiteral())
+	assert.True(t, template.Segments[1].IsParam())
+	assert.False(t, template.Segments[3].IsParam())
+	assert.False(t, template.Segments[3].IsLiteral())
+	assert.Equal(t, []string{"id", "photo", "ext"}, template.Params())
+
+	assert.Empty(t, ParsePathTemplate("/").Segments)
+	assert.Empty(t, ParsePathTemplate("/pets/{id").Params())
+}
+
+func TestCompareRoutes(t *testing.T) {
+	t.Parallel()
+
+	for _, fixture := range []struct {
+		Path, Other string
+		Kind        RouteConflictKind
+		Conflicting bool
+	}{
+		{Path: "/files/readme.md", Other: "/files/{name}.{ext}", Kind: RouteShadowing, Conflicting: true},
+		{Path: "/files/{name}.{ext}", Other: "/files/{id}", Kind: RouteShadowing, Conflicting: true},
+		{Path: "/files/{a}.{b}", Other: "/files/{c}.{d}", Kind: RouteEquivalent, Conflicting: true},
+		{Path: "/files/readme", Other: "/files/{name}.{ext}"},
+		{Path: "/files/{a}.{b}", Other: "/files/{a}-{b}"},
+		{Path: "/files/a.b", Other: "/files/{name}.(ext)"},
+	} {
+		kind, ok := compareRoutes(ParsePathTemplate(fixture.Path), ParsePathTemplate(fixture.Other))
+		assert.Equalf(t, fixture.Conflicting, ok, "%s vs %s", fixture.Path, fixture.Other)
+		assert.Equalf(t, fixture.Kind, kind, "%s vs %s", fixture.Path, fixture.Other)
+
+		kind, ok = compareRoutes(ParsePathTemplate(fixture.Other), ParsePathTemplate(fixture.Path))
+		assert.Equalf(t, fixture.Conflicting, ok, "%s vs %s", fixture.Other, fixture.Path)
+		assert.Equalf(t, fixture.Kind, kind, "%s vs %s", fixture.Other, fixture.Path)
+	}
+}
+
+func TestAnalyzer_PathTemplates(t *testing.T) {
+	t.Parallel()
+
+	doc := antest.LoadOrFail(t, filepath.Join("fixtures", "routes.yml"))
+	an := New(doc)
+
+	t.Run("should parse all paths", func(t *testing.T) {
+		templates := an.PathTemplates()
+		require.Len(t, templates, 5)
+		assert.Equal(t, "/pets/mine", templates[0].Path)
+	})
+
+	t.Run("should check path parameters", func(t *testing.T) {
+		assert.Equal(t, []PathParamMismatch{
+			{Method: "PUT", Path: "/pets/{id}/photos/{photo}.{ext}", Name: "ext", InTemplate: true},
+			{Method: "PUT", Path: "/pets/{id}/photos/{photo}.{ext}", Name: "owner"},
+			{Method: "POST", Path: "/stores/{store}/items", Name: "store", InTemplate: true},
+		}, an.PathParamMismatches())
+	})
+
+	t.Run("should detect route conflicts", func(t *testing.T) {
+		assert.Equal(t, []RouteConflict{
+			{Kind: RouteShadowing, Path: "/pets/mine", Other: "/pets/{id}", Methods: []string{"GET"}},
+			{Kind: RouteShadowing, Path: "/pets/mine", Other: "/pets/{name}", Methods: []string{"GET"}},
+			{Kind: RouteEquivalent, Path: "/pets/{id}", Other: "/pets/{name}", Methods: []string{"GET"}},
+		}, an.RouteConflicts())
+	})
+}
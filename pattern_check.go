@@ -0,0 +1,183 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"strings"
+)
+
+// PatternIssueKind qualifies an issue found with a pattern.
+type PatternIssueKind string
+
+const (
+	// PatternInvalid reports a pattern which does not compile.
+	PatternInvalid PatternIssueKind = "invalid"
+
+	// PatternUnsupported reports a pattern using ECMA-262 constructs which are not supported by
+	// RE2 (and Go), such as lookarounds or backreferences.
+	PatternUnsupported PatternIssueKind = "unsupported"
+
+	// PatternNotECMA reports a pattern using RE2 constructs which are not supported by ECMA-262,
+	// such as inline flags or POSIX character classes.
+	PatternNotECMA PatternIssueKind = "not-ecma"
+
+	// PatternCatastrophic reports a pattern likely to trigger catastrophic backtracking
+	// in backtracking regular expression engines, such as nested unbounded quantifiers.
+	PatternCatastrophic PatternIssueKind = "catastrophic-backtracking"
+)
+
+// PatternIssue reports an issue with the pattern located at Key.
+type PatternIssue struct {
+	// Key is the JSON pointer to the construct declaring the pattern, as in [Spec.AllPatterns].
+	Key     string
+	Pattern string
+	Kind    PatternIssueKind
+	Message string
+}
+
+// CheckPatterns checks all the patterns found in the spec.
+//
+// Patterns are compiled as Go (RE2) regular expressions, and checked for compatibility with
+// ECMA-262 regular expressions, which is the dialect required by JSON schema.
+//
+// Issues are sorted by key.
+func (s *Spec) CheckPatterns() []PatternIssue {
+	var issues []PatternIssue
+	for _, key := range sortedKeys(s.patterns.allPatterns) {
+		issues = append(issues, checkPattern(key, s.patterns.allPatterns[key])...)
+	}
+
+	return issues
+}
+
+func checkPattern(key, pattern string) []PatternIssue {
+	var issues []PatternIssue
+	report := func(kind PatternIssueKind, message string) {
+		issues = append(issues, PatternIssue{Key: key, Pattern: pattern, Kind: kind, Message: message})
+	}
+
+	constructs := scanPattern(pattern)
+	for _, construct := range constructs.ecmaOnly {
+		report(PatternUnsupported, construct+" is not supported by RE2")
+	}
+
+	for _, construct := range constructs.re2Only {
+		report(PatternNotECMA, construct+" is not supported by ECMA-262")
+	}
+
+	if _, err := regexp.Compile(pattern); err != nil {
+		if len(constructs.ecmaOnly) == 0 {
+			report(PatternInvalid, err.Error())
+		}
+
+		return issues
+	}
+
+	// capturing groups prevent the parser from squashing nested repetitions such as (?:a+)+
+	re, err := syntax.Parse(constructs.capturing, syntax.Perl)
+	if err == nil && hasNestedRepeat(re, false) {
+		report(PatternCatastrophic, "nested unbounded quantifiers may cause catastrophic backtracking")
+	}
+
+	return issues
+}
+
+// patternConstructs holds the dialect-specific constructs found in a pattern.
+type patternConstructs struct {
+	ecmaOnly []string
+	re2Only  []string
+
+	// capturing is the pattern with all non-capturing groups turned into capturing groups
+	capturing string
+}
+
+// scanPattern finds constructs specific to either the ECMA-262 or RE2 regular expression dialect.
+func scanPattern(pattern string) patternConstructs {
+	var (
+		constructs patternConstructs
+		capturing  strings.Builder
+		inClass    bool
+	)
+
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		rest := pattern[i:]
+
+		switch {
+		case c == '\\' && i+1 < len(pattern):
+			next := pattern[i+1]
+			switch {
+			case inClass:
+			case next >= '1' && next <= '9':
+				constructs.ecmaOnly = append(constructs.ecmaOnly, "backreference "+rest[:2])
+			case next == 'k' && strings.HasPrefix(rest[2:], "<"):
+				constructs.ecmaOnly = append(constructs.ecmaOnly, "named backreference")
+			case next == 'A' || next == 'z' || next == 'Q' || next == 'C':
+				constructs.re2Only = append(constructs.re2Only, "escape "+rest[:2])
+			}
+			capturing.WriteString(rest[:2])
+			i++
+
+			continue
+
+		case inClass:
+			if strings.HasPrefix(rest, "[:") {
+				constructs.re2Only = append(constructs.re2Only, "POSIX character class")
+			}
+			inClass = c != ']'
+
+		case c == '[':
+			inClass = true
+			if strings.HasPrefix(rest, "[]") || strings.HasPrefix(rest, "[^]") {
+				// a leading ']' is a literal in RE2
+				capturing.WriteString(rest[:strings.IndexByte(rest, ']')+1])
+				i += strings.IndexByte(rest, ']')
+
+				continue
+			}
+
+		case strings.HasPrefix(rest, "(?=") || strings.HasPrefix(rest, "(?!"):
+			constructs.ecmaOnly = append(constructs.ecmaOnly, "lookahead")
+
+		case strings.HasPrefix(rest, "(?<=") || strings.HasPrefix(rest, "(?<!"):
+			constructs.ecmaOnly = append(constructs.ecmaOnly, "lookbehind")
+
+		case strings.HasPrefix(rest, "(?P<"):
+			constructs.re2Only = append(constructs.re2Only, "named group (?P<name>)")
+
+		case strings.HasPrefix(rest, "(?:"):
+			capturing.WriteByte('(')
+			i += 2
+
+			continue
+
+		case strings.HasPrefix(rest, "(?") && !strings.HasPrefix(rest, "(?<"):
+			constructs.re2Only = append(constructs.re2Only, "inline flags")
+		}
+
+		capturing.WriteByte(c)
+	}
+	constructs.capturing = capturing.String()
+
+	return constructs
+}
+
+// hasNestedRepeat is true when some unbounded repetition is nested in another repetition.
+func hasNestedRepeat(re *syntax.Regexp, inRepeat bool) bool {
+	unbounded := re.Op == syntax.OpStar || re.Op == syntax.OpPlus || (re.Op == syntax.OpRepeat && re.Max == -1)
+	if unbounded && inRepeat {
+		return true
+	}
+
+	repeated := inRepeat || unbounded || (re.Op == syntax.OpRepeat && re.Max > 1)
+	for _, sub := range re.Sub {
+		if hasNestedRepeat(sub, repeated) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/analysis/internal/antest"
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestCheckPattern(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		pattern  string
+		expected []PatternIssueKind
+	}{
+		{pattern: `^[a-z][a-z0-9_-]*$`},
+		{pattern: `^\d{3}-\d{4}$`},
+		{pattern: `^(?<year>\d{4})-(?<month>\d{2})$`},
+		{pattern: `[(?=x)]\(?:a+\)+`},
+		{pattern: `^(a{1,3}){2}$`},
+		{pattern: `a[A-Za-Z0-9]+`, expected: []PatternIssueKind{PatternInvalid}},
+		{pattern: `(abc`, expected: []PatternIssueKind{PatternInvalid}},
+		{pattern: `^(?=.*\d)[a-z\d]{8,}$`, expected: []PatternIssueKind{PatternUnsupported}},
+		{pattern: `^(?!admin).+(?<!\.tmp)$`, expected: []PatternIssueKind{PatternUnsupported, PatternUnsupported}},
+		{pattern: `^(['"]).*\1$`, expected: []PatternIssueKind{PatternUnsupported}},
+		{pattern: `^(?<q>['"]).*\k<q>$`, expected: []PatternIssueKind{PatternUnsupported}},
+		{pattern: `(?i)^abc$`, expected: []PatternIssueKind{PatternNotECMA}},
+		{pattern: `^(?P<word>[[:alpha:]]+)\z`, expected: []PatternIssueKind{PatternNotECMA, PatternNotECMA, PatternNotECMA}},
+		{pattern: `^(a+)+$`, expected: []PatternIssueKind{PatternCatastrophic}},
+		{pattern: `^(?:\w+\s?)*$`, expected: []PatternIssueKind{PatternCatastrophic}},
+		{pattern: `^(?:a*){2,5}b$`, expected: []PatternIssueKind{PatternCatastrophic}},
+		{pattern: `(?s)^(.*,)*$`, expected: []PatternIssueKind{PatternCatastrophic, PatternNotECMA}},
+	} {
+		t.Run(tc.pattern, func(t *testing.T) {
+			issues := checkPattern("#/definitions/sample", tc.pattern)
+
+			kinds := make([]PatternIssueKind, 0, len(issues))
+			for _, issue := range issues {
+				assert.Equal(t, "#/definitions/sample", issue.Key)
+				assert.Equal(t, tc.pattern, issue.Pattern)
+				assert.NotEmpty(t, issue.Message)
+				kinds = append(kinds, issue.Kind)
+			}
+			assert.ElementsMatch(t, tc.expected, kinds)
+		})
+	}
+}
+
+func TestAnalyzer_CheckPatterns(t *testing.T) {
+	t.Parallel()
+
+	doc := antest.LoadOrFail(t, filepath.Join("fixtures", "patterns.yml"))
+	issues := New(doc).CheckPatterns()
+
+	require.Len(t, issues, 1)
+	assert.Equal(t, PatternIssue{
+		Key:     "#/parameters/idParam",
+		Pattern: "a[A-Za-Z0-9]+",
+		Kind:    PatternInvalid,
+		Message: "error parsing regexp: invalid character class range: `a-Z`",
+	}, issues[0])
+}
@@ -0,0 +1,182 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/jsonpointer"
+	yaml "go.yaml.in/yaml/v3"
+)
+
+// Position locates a construct in a source document.
+//
+// Lines and columns start at 1.
+type Position struct {
+	File   string `json:"file,omitempty"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+}
+
+// IsValid is true when the position refers to some line.
+func (p Position) IsValid() bool {
+	return p.Line > 0
+}
+
+func (p Position) String() string {
+	if p.File == "" {
+		return fmt.Sprintf("%d:%d", p.Line, p.Column)
+	}
+
+	return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+}
+
+// PositionMap associates JSON pointers with their position in the source documents of a spec.
+//
+// The map knows about the root document of the spec, and possibly about other documents
+// referred to by remote $ref.
+//
+// A PositionMap is not safe for concurrent updates, but is safe for concurrent lookups.
+type PositionMap struct {
+	root      string
+	documents map[string]map[string]Position
+}
+
+// NewPositionMap builds the positions of all the JSON pointers in the root document of a spec,
+// provided as raw YAML or JSON bytes.
+//
+// The file is the location of the document, as reported in positions.
+func NewPositionMap(file string, data []byte) (*PositionMap, error) {
+	m := &PositionMap{
+		root:      file,
+		documents: make(map[string]map[string]Position, 1),
+	}
+
+	if err := m.AddDocument(file, data); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// AddDocument builds the positions of all the JSON pointers in another document, provided as raw YAML or JSON bytes.
+//
+// The file must be the location of the document as it appears in remote $ref, e.g. "models.yaml" for
+// "$ref": "models.yaml#/definitions/Pet".
+func (m *PositionMap) AddDocument(file string, data []byte) error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return ErrPositions(file, err)
+	}
+
+	positions := make(map[string]Position, allocLargeMap)
+	walkPositions(&doc, "", file, positions)
+	m.documents[file] = positions
+
+	return nil
+}
+
+// Position returns the position of the construct located by a JSON pointer, e.g. "#/paths/~1pets/get",
+// or by a remote $ref to another known document, e.g. "models.yaml#/definitions/Pet".
+//
+// When the pointer does not exist in the source (e.g. a definition created by [Flatten]), the position
+// of the closest enclosing construct is returned.
+func (m *PositionMap) Position(pointer string) (Position, bool) {
+	positions, fragment, ok := m.document(pointer)
+	if !ok {
+		return Position{}, false
+	}
+
+	for {
+		if pos, found := positions[fragment]; found {
+			return pos, true
+		}
+
+		index := strings.LastIndexByte(fragment, '/')
+		if index < 0 {
+			return Position{}, false
+		}
+		fragment = fragment[:index]
+	}
+}
+
+// Contains is true when the construct located by a JSON pointer exists in the source documents.
+func (m *PositionMap) Contains(pointer string) bool {
+	positions, fragment, ok := m.document(pointer)
+	if !ok {
+		return false
+	}
+
+	_, found := positions[fragment]
+
+	return found
+}
+
+// document yields the positions in the document a pointer refers to, and the fragment of this pointer.
+func (m *PositionMap) document(pointer string) (map[string]Position, string, bool) {
+	if m == nil {
+		return nil, "", false
+	}
+
+	file, fragment, _ := strings.Cut(pointer, "#")
+	if file == "" {
+		file = m.root
+	}
+
+	positions, ok := m.documents[file]
+
+	return positions, strings.TrimSuffix(fragment, "/"), ok
+}
+
+// walkPositions records the position of every node under pointer.
+//
+// The position of a member of a mapping is the position of its key. Aliases are not followed.
+func walkPositions(node *yaml.Node, pointer, file string, positions map[string]Position) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		positions[pointer] = Position{File: file, Line: node.Line, Column: node.Column}
+		for _, content := range node.Content {
+			walkPositions(content, pointer, file, positions)
+		}
+
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			child := pointer + "/" + jsonpointer.Escape(key.Value)
+			positions[child] = Position{File: file, Line: key.Line, Column: key.Column}
+			walkPositions(value, child, file, positions)
+		}
+
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			child := pointer + "/" + strconv.Itoa(i)
+			positions[child] = Position{File: file, Line: item.Line, Column: item.Column}
+			walkPositions(item, child, file, positions)
+		}
+	}
+}
+
+// PositionOf returns the position in the source documents of the construct located by a JSON pointer,
+// when the spec is analyzed with [WithPositions].
+//
+// See [PositionMap.Position].
+func (s *Spec) PositionOf(pointer string) (Position, bool) {
+	return s.positions.Position(pointer)
+}
+
+// locateIssues sets the source location of issues, when known.
+func locateIssues(issues []Issue, positions *PositionMap) {
+	for i := range issues {
+		pos, ok := positions.Position(issues[i].Pointer)
+		if !ok {
+			continue
+		}
+
+		issues[i].File = pos.File
+		issues[i].Line = pos.Line
+		issues[i].Column = pos.Column
+	}
+}
@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/analysis/internal/antest"
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestPositionMap(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should map YAML documents", func(t *testing.T) {
+		t.Parallel()
+
+		positions := loadPositions(t, filepath.Join("fixtures", "flatten-issues.yml"))
+
+		pos, ok := positions.Position("#/definitions/item/properties/error/$ref")
+		require.TrueT(t, ok)
+		assert.Equal(t, Position{File: filepath.Join("fixtures", "flatten-issues.yml"), Line: 18, Column: 9}, pos)
+		assert.TrueT(t, positions.Contains("#/definitions/item/properties/error"))
+
+		pos, ok = positions.Position("#/paths/~1items/get/responses/200")
+		require.TrueT(t, ok)
+		assert.EqualT(t, 9, pos.Line)
+
+		t.Run("with the closest enclosing construct", func(t *testing.T) {
+			pos, ok := positions.Position("#/definitions/itemOAIGen")
+			require.TrueT(t, ok)
+			assert.EqualT(t, 13, pos.Line)
+			assert.FalseT(t, positions.Contains("#/definitions/itemOAIGen"))
+		})
+
+		t.Run("with the root document", func(t *testing.T) {
+			pos, ok := positions.Position("#")
+			require.TrueT(t, ok)
+			assert.EqualT(t, filepath.Join("fixtures", "flatten-issues.yml")+":1:1", pos.String())
+		})
+	})
+
+	t.Run("should map JSON documents", func(t *testing.T) {
+		t.Parallel()
+
+		positions, err := NewPositionMap("spec.json", []byte(`{
+  "swagger": "2.0",
+  "paths": {
+    "/pets": {
+      "get": {
+        "parameters": [{"name": "limit", "in": "query"}, {"name": "sort", "in": "query"}]
+      }
+    }
+  }
+}`))
+		require.NoError(t, err)
+
+		pos, ok := positions.Position("#/paths/~1pets/get/parameters/1")
+		require.TrueT(t, ok)
+		assert.Equal(t, Position{File: "spec.json", Line: 6, Column: 58}, pos)
+	})
+
+	t.Run("should map remote documents", func(t *testing.T) {
+		t.Parallel()
+
+		positions, err := NewPositionMap("spec.yaml", []byte("swagger: '2.0'\n"))
+		require.NoError(t, err)
+		require.NoError(t, positions.AddDocument("models.yaml", []byte("definitions:\n  Pet:\n    type: object\n")))
+
+		pos, ok := positions.Position("models.yaml#/definitions/Pet/type")
+		require.TrueT(t, ok)
+		assert.Equal(t, Position{File: "models.yaml", Line: 3, Column: 5}, pos)
+
+		_, ok = positions.Position("other.yaml#/definitions/Pet")
+		assert.FalseT(t, ok)
+	})
+
+	t.Run("should fail on invalid documents", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewPositionMap("invalid.yaml", []byte("swagger: [\n"))
+		require.ErrorIs(t, err, ErrAnalysis)
+	})
+
+	t.Run("should not find anything without positions", func(t *testing.T) {
+		t.Parallel()
+
+		var positions *PositionMap
+		_, ok := positions.Position("#/paths")
+		assert.FalseT(t, ok)
+		assert.FalseT(t, positions.Contains("#/paths"))
+	})
+}
+
+func TestPositions_Issues(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should locate analyzer issues", func(t *testing.T) {
+		t.Parallel()
+
+		fixture := filepath.Join("fixtures", "routes.yml")
+		doc := antest.LoadOrFail(t, fixture)
+		an := New(doc, WithPositions(loadPositions(t, fixture)))
+
+		pos, ok := an.PositionOf("#/paths/~1pets~1{name}")
+		require.TrueT(t, ok)
+		assert.EqualT(t, 27, pos.Line)
+
+		issues := an.Issues()
+		require.Len(t, issues, 6)
+		assert.EqualT(t, fixture, issues[2].File)
+		assert.EqualT(t, 13, issues[2].Line)
+		assert.EqualT(t, 3, issues[2].Column)
+
+		snapshot := an.Snapshot()
+		assert.Equal(t, issues, snapshot.Issues())
+	})
+
+	t.Run("should locate flatten issues", func(t *testing.T) {
+		t.Parallel()
+
+		fixture := filepath.Join("fixtures", "flatten-issues.yml")
+		doc := antest.LoadOrFail(t, fixture)
+
+		issues, err := FlattenWithIssues(FlattenOpts{
+			Spec:      New(doc),
+			BasePath:  fixture,
+			Minimal:   true,
+			Positions: loadPositions(t, fixture),
+		})
+		require.NoError(t, err)
+		require.Len(t, issues, 1)
+		assert.EqualT(t, fixture+":17:7: warning: #/definitions/item/properties/error [flatten/ref-as-schema] "+
+			`found $ref "#/responses/error" (response) interpreted as schema`, issues[0].String())
+	})
+}
+
+func loadPositions(t testing.TB, file string) *PositionMap {
+	t.Helper()
+
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+
+	positions, err := NewPositionMap(file, data)
+	require.NoError(t, err)
+
+	return positions
+}
@@ -0,0 +1,440 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"maps"
+	slashpath "path"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/jsonpointer"
+	"github.com/go-openapi/spec"
+)
+
+// Reanalyze updates the indices of the analyzed spec after the subtree located at the JSON pointer key
+// has been added, removed or replaced in the underlying document.
+//
+// The key is a JSON pointer such as "#/definitions/Pet", "#/paths/~1pets/get" or
+// "#/paths/~1pets/get/parameters/0/schema". The leading "#" is optional.
+//
+// Only the smallest indexed node enclosing the key (a schema, a parameter, a response,
+// an operation or a path item) is analyzed again: index entries located under that node are dropped,
+// then rebuilt from the current content of the document. When the node no longer exists,
+// its entries are simply dropped.
+//
+// When the key designates an element of an array, e.g. an allOf member or an operation parameter,
+// the node holding the array is analyzed again: removing an element shifts the keys of the following ones.
+//
+// Keys pointing to an entire section (e.g. "#/definitions") or to the document root trigger
+// a full reload of the analysis.
+//
+// Reanalyze panics with [ErrImmutableSpec] when called on a [Snapshot].
+func (s *Spec) Reanalyze(key string) {
+	s.mustBeMutable()
+
+	parts := pointerParts(key)
+	if isArrayElement(parts) {
+		parts = parts[:len(parts)-2]
+	}
+
+	if len(parts) < 2 && isAnalyzedSection(parts) {
+		s.reload()
+
+		return
+	}
+
+	nodeKey, analyze := s.locateNode(parts)
+	if nodeKey != "" {
+		s.forgetKeys("#" + nodeKey)
+	}
+
+	if analyze != nil {
+		analyze()
+	}
+
+	s.usages.schemas = nil
+	s.analyzeMediaAndSecurity()
+}
+
+// locateNode finds the smallest indexed node which encloses the location described by parts.
+//
+// It returns the key of this node and a function to analyze it again. A nil function is returned
+// whenever the node has been removed from the document.
+func (s *Spec) locateNode(parts []string) (string, func()) {
+	if len(parts) == 0 {
+		return "", nil
+	}
+
+	switch parts[0] {
+	case "definitions":
+		name := parts[1]
+		key := slashpath.Join("/definitions", jsonpointer.Escape(name))
+		schema, ok := s.spec.Definitions[name]
+		if !ok {
+			return key, nil
+		}
+
+		return s.locateSchema(name, &schema, "/definitions", parts[2:])
+
+	case "parameters":
+		name := parts[1]
+		key := slashpath.Join("/parameters", jsonpointer.Escape(name))
+		param, ok := s.spec.Parameters[name]
+		if !ok {
+			return key, nil
+		}
+
+		if isSchemaPart(parts[2:]) && param.In == "body" && param.Schema != nil {
+			return s.locateSchema("schema", param.Schema, key, parts[3:])
+		}
+
+		return key, func() { s.analyzeSharedParameter(name, param) }
+
+	case "responses":
+		name := parts[1]
+		key := slashpath.Join("/responses", jsonpointer.Escape(name))
+		response, ok := s.spec.Responses[name]
+		if !ok {
+			return key, nil
+		}
+
+		if isSchemaPart(parts[2:]) && response.Schema != nil {
+			return s.locateSchema("schema", response.Schema, key, parts[3:])
+		}
+
+		return key, func() { s.analyzeSharedResponse(name, response) }
+
+	case "paths":
+		return s.locatePathItem(parts[1], parts[2:])
+
+	default:
+		// not an indexed section: only media types and security schemes need a refresh
+		return "", nil
+	}
+}
+
+func (s *Spec) locatePathItem(path string, rest []string) (string, func()) {
+	key := slashpath.Join("/paths", jsonpointer.Escape(path))
+	var (
+		pathItem spec.PathItem
+		ok       bool
+	)
+	if s.spec.Paths != nil {
+		pathItem, ok = s.spec.Paths.Paths[path]
+	}
+
+	analyzePathItem := func() {
+		for method := range s.operations {
+			delete(s.operations[method], path)
+		}
+
+		if ok {
+			s.analyzeOperations(path, &pathItem)
+		}
+	}
+
+	if !ok || len(rest) == 0 {
+		return key, analyzePathItem
+	}
+
+	if rest[0] == "parameters" {
+		idx, isIndex := indexPart(rest[1:], len(pathItem.Parameters))
+		if !isIndex {
+			return key, analyzePathItem
+		}
+
+		param := pathItem.Parameters[idx]
+		paramKey := slashpath.Join(key, "parameters", strconv.Itoa(idx))
+		if isSchemaPart(rest[2:]) && param.Schema != nil {
+			return s.locateSchema("schema", param.Schema, paramKey, rest[3:])
+		}
+
+		return paramKey, func() { s.analyzePathItemParameter(path, idx, param) }
+	}
+
+	method := strings.ToUpper(rest[0])
+	op := operationForMethod(&pathItem, method)
+	if op == nil {
+		return key, analyzePathItem
+	}
+
+	return s.locateOperation(method, path, op, rest[1:])
+}
+
+func (s *Spec) locateOperation(method, path string, op *spec.Operation, rest []string) (string, func()) {
+	key := slashpath.Join("/paths", jsonpointer.Escape(path), strings.ToLower(method))
+	analyzeOperation := func() {
+		delete(s.operations[method], path)
+		s.analyzeOperation(method, path, op)
+	}
+
+	if len(rest) < 2 { //nolint:mnd // a parameter or a response requires at least 2 more parts
+		return key, analyzeOperation
+	}
+
+	switch rest[0] {
+	case "parameters":
+		idx, isIndex := indexPart(rest[1:], len(op.Parameters))
+		if !isIndex {
+			return key, analyzeOperation
+		}
+
+		param := op.Parameters[idx]
+		paramKey := slashpath.Join(key, "parameters", strconv.Itoa(idx))
+		if isSchemaPart(rest[2:]) && param.In == "body" && param.Schema != nil {
+			return s.locateSchema("schema", param.Schema, paramKey, rest[3:])
+		}
+
+		return paramKey, func() { s.analyzeParameter(key, idx, param) }
+
+	case "responses":
+		if op.Responses == nil {
+			return key, analyzeOperation
+		}
+
+		var (
+			response *spec.Response
+			analyze  func()
+		)
+		if rest[1] == "default" {
+			response = op.Responses.Default
+			analyze = func() { s.analyzeDefaultResponse(key, response) }
+		} else if code, err := strconv.Atoi(rest[1]); err == nil {
+			if res, ok := op.Responses.StatusCodeResponses[code]; ok {
+				response = &res
+				analyze = func() { s.analyzeResponse(key, code, res) }
+			}
+		}
+
+		if response == nil {
+			return key, analyzeOperation
+		}
+
+		responseKey := slashpath.Join(key, "responses", rest[1])
+		if isSchemaPart(rest[2:]) && response.Schema != nil {
+			return s.locateSchema("schema", response.Schema, responseKey, rest[3:])
+		}
+
+		return responseKey, analyze
+
+	default:
+		return key, analyzeOperation
+	}
+}
+
+// locateSchema walks down a schema as far as the remaining parts designate a subschema.
+func (s *Spec) locateSchema(name string, schema *spec.Schema, prefix string, rest []string) (string, func()) {
+	for {
+		key := slashpath.Join(prefix, jsonpointer.Escape(name))
+		child, childName, consumed := subSchemaPart(schema, rest)
+		if child == nil {
+			return key, func() { s.analyzeSchema(name, schema, prefix) }
+		}
+
+		prefix = key
+		if consumed > 1 {
+			prefix = slashpath.Join(key, rest[0])
+		}
+		name, schema, rest = childName, child, rest[consumed:]
+	}
+}
+
+// subSchemaPart resolves the subschema designated by the leading parts, following the same layout as analyzeSchema.
+//
+// It returns the subschema, its name and the number of parts consumed.
+func subSchemaPart(schema *spec.Schema, parts []string) (*spec.Schema, string, int) {
+	if len(parts) == 0 {
+		return nil, "", 0
+	}
+
+	mapped := func(m map[string]spec.Schema) (*spec.Schema, string, int) {
+		if len(parts) < 2 { //nolint:mnd // a map entry requires a name
+			return nil, "", 0
+		}
+
+		v, ok := m[parts[1]]
+		if !ok {
+			return nil, "", 0
+		}
+
+		return &v, parts[1], 2 //nolint:mnd
+	}
+
+	indexed := func(schemas []spec.Schema) (*spec.Schema, string, int) {
+		idx, ok := indexPart(parts[1:], len(schemas))
+		if !ok {
+			return nil, "", 0
+		}
+
+		return &schemas[idx], strconv.Itoa(idx), 2 //nolint:mnd
+	}
+
+	switch parts[0] {
+	case "definitions":
+		return mapped(schema.Definitions)
+	case "properties":
+		return mapped(schema.Properties)
+	case "patternProperties":
+		return mapped(schema.PatternProperties)
+	case "allOf":
+		return indexed(schema.AllOf)
+	case "anyOf":
+		return indexed(schema.AnyOf)
+	case "oneOf":
+		return indexed(schema.OneOf)
+	case "not":
+		if schema.Not != nil {
+			return schema.Not, "not", 1
+		}
+	case "additionalProperties":
+		if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+			return schema.AdditionalProperties.Schema, "additionalProperties", 1
+		}
+	case "additionalItems":
+		if schema.AdditionalItems != nil && schema.AdditionalItems.Schema != nil {
+			return schema.AdditionalItems.Schema, "additionalItems", 1
+		}
+	case "items":
+		if schema.Items == nil {
+			break
+		}
+
+		if schema.Items.Schema != nil {
+			return schema.Items.Schema, "items", 1
+		}
+
+		return indexed(schema.Items.Schemas)
+	}
+
+	return nil, "", 0
+}
+
+// forgetKeys removes from all indices the entries located at or under key.
+func (s *Spec) forgetKeys(key string) {
+	forgetKeysIn(s.allSchemas, key)
+	forgetKeysIn(s.allOfs, key)
+	forgetKeysIn(s.usages.roots, key)
+
+	for _, m := range []map[string]spec.Ref{
+		s.references.schemas, s.references.responses, s.references.parameters, s.references.items,
+		s.references.headerItems, s.references.parameterItems, s.references.allRefs, s.references.pathItems,
+	} {
+		forgetKeysIn(m, key)
+	}
+
+	for _, m := range []map[string]string{
+		s.patterns.parameters, s.patterns.headers, s.patterns.items, s.patterns.schemas, s.patterns.allPatterns,
+	} {
+		forgetKeysIn(m, key)
+	}
+
+	for _, m := range []map[string]spec.Extensions{
+		s.extensions.schemas, s.extensions.parameters, s.extensions.headers, s.extensions.items,
+		s.extensions.operations, s.extensions.pathItems, s.extensions.responses, s.extensions.allExtensions,
+	} {
+		forgetKeysIn(m, key)
+	}
+
+	for _, m := range []map[string][]any{
+		s.enums.parameters, s.enums.headers, s.enums.items, s.enums.schemas, s.enums.allEnums,
+	} {
+		forgetKeysIn(m, key)
+	}
+}
+
+func forgetKeysIn[T any](m map[string]T, key string) {
+	maps.DeleteFunc(m, func(k string, _ T) bool {
+		return k == key || strings.HasPrefix(k, key+"/")
+	})
+}
+
+func operationForMethod(pathItem *spec.PathItem, method string) *spec.Operation {
+	switch method {
+	case "GET":
+		return pathItem.Get
+	case "PUT":
+		return pathItem.Put
+	case "POST":
+		return pathItem.Post
+	case "PATCH":
+		return pathItem.Patch
+	case "DELETE":
+		return pathItem.Delete
+	case "HEAD":
+		return pathItem.Head
+	case "OPTIONS":
+		return pathItem.Options
+	default:
+		return nil
+	}
+}
+
+// pointerParts splits a JSON pointer key into its unescaped parts.
+func pointerParts(key string) []string {
+	key = strings.TrimPrefix(key, "#")
+	key = strings.Trim(key, "/")
+	if key == "" {
+		return nil
+	}
+
+	parts := strings.Split(key, "/")
+	for i, part := range parts {
+		parts[i] = jsonpointer.Unescape(part)
+	}
+
+	return parts
+}
+
+func isAnalyzedSection(parts []string) bool {
+	if len(parts) == 0 {
+		return true
+	}
+
+	switch parts[0] {
+	case "definitions", "parameters", "responses", "paths":
+		return true
+	default:
+		return false
+	}
+}
+
+// isArrayElement is true when parts designate an element of an indexed array: an allOf, anyOf or oneOf member,
+// a tuple item, or a parameter of an operation or a path item.
+//
+// Map entries which happen to look like array elements merely cause a larger node to be analyzed again.
+func isArrayElement(parts []string) bool {
+	const minParts = 3 // e.g. /paths/{path}/parameters/{index}
+	if len(parts) < minParts {
+		return false
+	}
+
+	if _, err := strconv.Atoi(parts[len(parts)-1]); err != nil {
+		return false
+	}
+
+	switch parts[len(parts)-2] {
+	case "allOf", "anyOf", "oneOf", "items", "parameters":
+		return true
+	default:
+		return false
+	}
+}
+
+func isSchemaPart(parts []string) bool {
+	return len(parts) > 0 && parts[0] == "schema"
+}
+
+func indexPart(parts []string, size int) (int, bool) {
+	if len(parts) == 0 {
+		return 0, false
+	}
+
+	idx, err := strconv.Atoi(parts[0])
+	if err != nil || idx < 0 || idx >= size {
+		return 0, false
+	}
+
+	return idx, true
+}
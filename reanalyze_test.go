@@ -0,0 +1,183 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/analysis/internal/antest"
+	"github.com/go-openapi/spec"
+	"github.com/go-openapi/testify/v2/assert"
+)
+
+func TestReanalyze(t *testing.T) {
+	t.Parallel()
+
+	for _, fixture := range []struct {
+		Title  string
+		File   string
+		Key    string
+		Mutate func(*spec.Swagger)
+	}{
+		{
+			Title: "add definition",
+			File:  "definitions.yml",
+			Key:   "#/definitions/added",
+			Mutate: func(sp *spec.Swagger) {
+				sp.Definitions["added"] = *spec.RefProperty("#/definitions/tag").WithPattern("^a$")
+			},
+		},
+		{
+			Title: "remove definition",
+			File:  "definitions.yml",
+			Key:   "#/definitions/withAllOf",
+			Mutate: func(sp *spec.Swagger) {
+				delete(sp.Definitions, "withAllOf")
+			},
+		},
+		{
+			Title: "replace nested property",
+			File:  "definitions.yml",
+			Key:   "#/definitions/tag/definitions/category/properties/value",
+			Mutate: func(sp *spec.Swagger) {
+				sch := sp.Definitions["tag"]
+				sch.Definitions["category"].Properties["value"] = *spec.RefSchema("#/definitions/withNot")
+			},
+		},
+		{
+			Title: "remove allOf member",
+			File:  "definitions.yml",
+			Key:   "#/definitions/withAllOf/allOf/1",
+			Mutate: func(sp *spec.Swagger) {
+				sch := sp.Definitions["withAllOf"]
+				sch.AllOf = sch.AllOf[:1]
+				sp.Definitions["withAllOf"] = sch
+			},
+		},
+		{
+			Title: "remove first allOf member",
+			File:  "definitions.yml",
+			Key:   "#/definitions/withAllOf/allOf/0",
+			Mutate: func(sp *spec.Swagger) {
+				sch := sp.Definitions["withAllOf"]
+				sch.AllOf = sch.AllOf[1:]
+				sp.Definitions["withAllOf"] = sch
+			},
+		},
+		{
+			Title: "remove first operation parameter",
+			File:  "definitions.yml",
+			Key:   "#/paths/~1some~1where~1{id}/get/parameters/0",
+			Mutate: func(sp *spec.Swagger) {
+				op := sp.Paths.Paths["/some/where/{id}"].Get
+				op.Parameters = op.Parameters[1:]
+			},
+		},
+		{
+			Title: "replace body parameter schema",
+			File:  "definitions.yml",
+			Key:   "#/paths/~1some~1where~1{id}/get/parameters/1/schema",
+			Mutate: func(sp *spec.Swagger) {
+				sp.Paths.Paths["/some/where/{id}"].Get.Parameters[1].Schema = spec.RefSchema("#/definitions/tag")
+			},
+		},
+		{
+			Title: "replace response header",
+			File:  "references.yml",
+			Key:   "#/paths/~1some~1where~1{id}/get/responses/default/headers/x-array-header",
+			Mutate: func(sp *spec.Swagger) {
+				resp := sp.Paths.Paths["/some/where/{id}"].Get.Responses.Default
+				delete(resp.Headers, "x-array-header")
+			},
+		},
+		{
+			Title: "add operation",
+			File:  "references.yml",
+			Key:   "#/paths/~1some~1where~1{id}/post",
+			Mutate: func(sp *spec.Swagger) {
+				pi := sp.Paths.Paths["/some/where/{id}"]
+				pi.Post = spec.NewOperation("addSome").
+					WithConsumes("application/x-added").
+					SecuredWith("added", "read").
+					AddParam(spec.BodyParam("body", spec.RefSchema("#/definitions/tag")))
+				sp.Paths.Paths["/some/where/{id}"] = pi
+			},
+		},
+		{
+			Title: "remove path item",
+			File:  "references.yml",
+			Key:   "#/paths/~1some~1where~1{id}",
+			Mutate: func(sp *spec.Swagger) {
+				delete(sp.Paths.Paths, "/some/where/{id}")
+			},
+		},
+		{
+			Title: "replace shared parameter",
+			File:  "patterns.yml",
+			Key:   "#/parameters/idParam",
+			Mutate: func(sp *spec.Swagger) {
+				param := sp.Parameters["idParam"]
+				param.Pattern = "^[0-9]+$"
+				sp.Parameters["idParam"] = param
+			},
+		},
+		{
+			Title: "replace enum in shared response",
+			File:  "enums.yml",
+			Key:   "#/responses/notFound/headers/ContentLength",
+			Mutate: func(sp *spec.Swagger) {
+				resp := sp.Responses["notFound"]
+				header := resp.Headers["ContentLength"]
+				header.Enum = []any{"1", "2"}
+				resp.Headers["ContentLength"] = header
+			},
+		},
+		{
+			Title: "whole section",
+			File:  "definitions.yml",
+			Key:   "#/definitions",
+			Mutate: func(sp *spec.Swagger) {
+				sp.Definitions = nil
+			},
+		},
+		{
+			Title: "not indexed section",
+			File:  "definitions.yml",
+			Key:   "/consumes",
+			Mutate: func(sp *spec.Swagger) {
+				sp.Consumes = []string{"application/x-added"}
+			},
+		},
+	} {
+		t.Run(fixture.Title, func(t *testing.T) {
+			t.Parallel()
+
+			doc := antest.LoadOrFail(t, filepath.Join("fixtures", fixture.File))
+			an := New(doc)
+
+			fixture.Mutate(doc)
+			an.Reanalyze(fixture.Key)
+
+			assertSameAnalysis(t, New(doc), an)
+		})
+	}
+}
+
+func assertSameAnalysis(t testing.TB, expected, actual *Spec) {
+	t.Helper()
+
+	assert.Equal(t, sortedKeys(expected.allSchemas), sortedKeys(actual.allSchemas))
+	assert.Equal(t, sortedKeys(expected.allOfs), sortedKeys(actual.allOfs))
+	assert.Equal(t, expected.references, actual.references)
+	assert.Equal(t, expected.patterns, actual.patterns)
+	assert.Equal(t, expected.enums, actual.enums)
+	assert.Equal(t, expected.extensions, actual.extensions)
+	assert.Equal(t, expected.usages.roots, actual.usages.roots)
+	assert.Equal(t, expected.schemaUsages(), actual.schemaUsages())
+	assert.Equal(t, expected.consumes, actual.consumes)
+	assert.Equal(t, expected.produces, actual.produces)
+	assert.Equal(t, expected.authSchemes, actual.authSchemes)
+	assert.ElementsMatch(t, expected.OperationMethodPaths(), actual.OperationMethodPaths())
+}
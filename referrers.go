@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ReferrersOf returns the locations of all the $ref which directly depend on the JSON pointer,
+// e.g. "#/definitions/Pet".
+//
+// A $ref depends on a pointer whenever it points to this pointer, to some location nested under it
+// (e.g. "#/definitions/Pet/properties/name") or to some location enclosing it.
+//
+// Locations are JSON pointers such as "#/paths/~1pets/get/parameters/0/schema", returned in lexicographic order.
+func (s *Spec) ReferrersOf(pointer string) []string {
+	pointer = normalizePointer(pointer)
+	result := make([]string, 0, allocSmallMap)
+	for key, ref := range s.references.allRefs {
+		if isSameBranch(ref.String(), pointer) {
+			result = append(result, key)
+		}
+	}
+	slices.Sort(result)
+
+	return result
+}
+
+// TransitiveReferrersOf returns the locations of all the $ref which depend, directly or indirectly, on the
+// JSON pointer.
+//
+// A $ref located under a definition (resp. a shared parameter, response or path item) makes this enclosing
+// construct depend on the pointer: every $ref to this construct is then considered a referrer too.
+//
+// Locations are returned in lexicographic order.
+func (s *Spec) TransitiveReferrersOf(pointer string) []string {
+	seen := make(map[string]struct{}, allocSmallMap)
+	pending := []string{normalizePointer(pointer)}
+
+	for len(pending) > 0 {
+		current := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		for key, ref := range s.references.allRefs {
+			if _, ok := seen[key]; ok {
+				continue
+			}
+
+			if isSameBranch(ref.String(), current) {
+				seen[key] = struct{}{}
+				pending = append(pending, key)
+			}
+		}
+	}
+
+	result := make([]string, 0, len(seen))
+	for key := range seen {
+		result = append(result, key)
+	}
+	slices.Sort(result)
+
+	return result
+}
+
+// OperationsReferring returns the operations which depend, directly or indirectly, on the JSON pointer.
+//
+// Operations are reported as "METHOD path" (e.g. "GET /pets/{id}", like [Spec.OperationMethodPaths]),
+// in lexicographic order. Parameters and path item $ref declared at the path level affect all the
+// operations of this path.
+func (s *Spec) OperationsReferring(pointer string) []string {
+	set := make(map[string]struct{}, allocSmallMap)
+	pointer = normalizePointer(pointer)
+
+	for _, key := range append(s.TransitiveReferrersOf(pointer), pointer) {
+		parts := pointerParts(key)
+		if len(parts) < 2 || parts[0] != "paths" { //nolint:mnd // a path item requires at least 2 parts
+			continue
+		}
+
+		path := parts[1]
+		if len(parts) > 2 { //nolint:mnd
+			method := strings.ToUpper(parts[2])
+			if op, ok := s.operations[method][path]; ok && op != nil {
+				set[fmt.Sprintf("%s %s", method, path)] = struct{}{}
+
+				continue
+			}
+		}
+
+		// path-level construct: all the operations under this path are affected
+		for method, pathItem := range s.operations {
+			if _, ok := pathItem[path]; ok {
+				set[fmt.Sprintf("%s %s", method, path)] = struct{}{}
+			}
+		}
+	}
+
+	return s.sortedStructMapKeys(set)
+}
+
+func (s *Spec) sortedStructMapKeys(mp map[string]struct{}) []string {
+	result := s.structMapKeys(mp)
+	slices.Sort(result)
+
+	return result
+}
+
+// normalizePointer ensures a JSON pointer is expressed as a local $ref, e.g. "#/definitions/Pet".
+func normalizePointer(pointer string) string {
+	if strings.HasPrefix(pointer, "#") {
+		return pointer
+	}
+
+	return "#" + pointer
+}
+
+// isSameBranch is true when one of the JSON pointers is equal to or nested under the other one.
+func isSameBranch(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+
+	return a == b || strings.HasPrefix(a, b+"/") || strings.HasPrefix(b, a+"/")
+}
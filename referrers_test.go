@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/analysis/internal/antest"
+	"github.com/go-openapi/testify/v2/assert"
+)
+
+func TestReferrers(t *testing.T) {
+	t.Parallel()
+
+	doc := antest.LoadOrFail(t, filepath.Join("fixtures", "references.yml"))
+	an := New(doc)
+
+	t.Run("direct referrers", func(t *testing.T) {
+		assert.Equal(t, []string{
+			"#/definitions/tag/properties/audit",
+		}, an.ReferrersOf("#/definitions/record"))
+
+		assert.Equal(t, []string{
+			"#/paths/~1some~1where~1{id}/get/parameters/1/items",
+			"#/paths/~1some~1where~1{id}/get/parameters/2/items",
+			"#/paths/~1some~1where~1{id}/get/responses/default/headers/x-array-header/items",
+		}, an.ReferrersOf("/definitions/named"))
+
+		assert.Equal(t, []string{
+			"#/definitions/tag/properties/audit",
+		}, an.ReferrersOf("#/definitions/record/properties/createdAt"),
+			"a $ref to an enclosing location depends on a nested pointer",
+		)
+
+		assert.Equal(t, []string{
+			"#/paths/~1some~1where~1{id}/get/responses/200/schema",
+		}, an.ReferrersOf("#/definitions/tag/properties/id"))
+
+		assert.Empty(t, an.ReferrersOf("#/definitions/unknown"))
+	})
+
+	t.Run("transitive referrers", func(t *testing.T) {
+		assert.Equal(t, []string{
+			"#/definitions/tag/properties/audit",
+			"#/paths/~1some~1where~1{id}/get/responses/200/schema",
+		}, an.TransitiveReferrersOf("#/definitions/record"))
+
+		assert.Equal(t, []string{
+			"#/paths/~1some~1where~1{id}/get/responses/404",
+			"#/responses/notFound/schema",
+		}, an.TransitiveReferrersOf("#/definitions/error"))
+
+		assert.Equal(t, []string{
+			"#/paths/~1some~1where~1{id}/parameters/0",
+		}, an.TransitiveReferrersOf("#/parameters/idParam"))
+	})
+
+	t.Run("operations referring", func(t *testing.T) {
+		assert.Equal(t, []string{"GET /some/where/{id}"}, an.OperationsReferring("#/definitions/record"))
+		assert.Equal(t, []string{"GET /some/where/{id}"}, an.OperationsReferring("#/parameters/idParam"))
+		assert.Equal(t, []string{"GET /some/where/{id}"}, an.OperationsReferring("#/definitions/error"))
+		assert.Empty(t, an.OperationsReferring("#/parameters/unknown"))
+	})
+}
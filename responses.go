@@ -0,0 +1,304 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/go-openapi/spec"
+)
+
+// ErrorOnResponseFunc is a callback function to be invoked
+// whenever an error is encountered while resolving references
+// on responses or on the items of their headers.
+//
+// This function takes as input the [spec.Response] which triggered the
+// error and the error itself.
+//
+// If the callback function returns false, the calling function should bail.
+//
+// If it returns true, the calling function should continue evaluating responses.
+// A nil ErrorOnResponseFunc must be evaluated as equivalent to panic().
+type ErrorOnResponseFunc func(spec.Response, error) bool
+
+// ResponsesFor the specified method and path, with all $ref to responses resolved.
+//
+// $ref in the items of response headers are resolved too.
+//
+// Returns nil if there is no such operation.
+//
+// Assumes responses properly resolve references if any and that
+// such references actually resolve to a response object.
+// Otherwise, panics.
+func (s *Spec) ResponsesFor(method, path string) *spec.Responses {
+	return s.SafeResponsesFor(method, path, nil)
+}
+
+// SafeResponsesFor the specified method and path, with all $ref to responses resolved.
+//
+// Does not assume responses properly resolve references or that
+// such references actually resolve to a response object.
+//
+// Upon error, invoke a [ErrorOnResponseFunc] callback with the erroneous
+// response. If the callback is set to nil, panics upon errors.
+// Erroneous responses are not reported in the result.
+func (s *Spec) SafeResponsesFor(method, path string, callmeOnError ErrorOnResponseFunc) *spec.Responses {
+	op, ok := s.OperationFor(method, path)
+	if !ok || op == nil {
+		return nil
+	}
+
+	return s.resolveResponses(op.Responses, callmeOnError)
+}
+
+// ResponseFor the specified operation id and status code, with all $ref resolved.
+//
+// The default response is returned whenever the operation does not declare a response for this
+// status code.
+//
+// Assumes responses properly resolve references if any and that
+// such references actually resolve to a response object.
+// Otherwise, panics.
+func (s *Spec) ResponseFor(operationID string, status int) (spec.Response, bool) {
+	return s.SafeResponseFor(operationID, status, nil)
+}
+
+// SafeResponseFor the specified operation id and status code, with all $ref resolved.
+//
+// Upon error, invoke a [ErrorOnResponseFunc] callback with the erroneous
+// response. If the callback is set to nil, panics upon errors.
+//
+// When the response declared for this status code cannot be resolved, no response is returned:
+// the default response is used only for undeclared status codes.
+func (s *Spec) SafeResponseFor(operationID string, status int, callmeOnError ErrorOnResponseFunc) (spec.Response, bool) {
+	_, _, op, ok := s.OperationForName(operationID)
+	if !ok || op == nil {
+		return spec.Response{}, false
+	}
+
+	responses := s.resolveResponses(op.Responses, callmeOnError)
+	if responses == nil {
+		return spec.Response{}, false
+	}
+
+	if _, declared := op.Responses.StatusCodeResponses[status]; declared {
+		// a declared response which could not be resolved is not substituted by the default response
+		response, found := responses.StatusCodeResponses[status]
+
+		return response, found
+	}
+
+	if responses.Default != nil {
+		return *responses.Default, true
+	}
+
+	return spec.Response{}, false
+}
+
+func (s *Spec) resolveResponses(responses *spec.Responses, callmeOnError ErrorOnResponseFunc) *spec.Responses {
+	if responses == nil {
+		return nil
+	}
+
+	if callmeOnError == nil {
+		callmeOnError = func(_ spec.Response, err error) bool {
+			panic(err)
+		}
+	}
+
+	result := &spec.Responses{
+		VendorExtensible: spec.VendorExtensible{Extensions: maps.Clone(responses.Extensions)},
+		ResponsesProps: spec.ResponsesProps{
+			StatusCodeResponses: make(map[int]spec.Response, len(responses.StatusCodeResponses)),
+		},
+	}
+
+	if responses.Default != nil {
+		resolved, err := s.resolveResponse(*responses.Default)
+		switch {
+		case err == nil:
+			result.Default = &resolved
+		case !callmeOnError(*responses.Default, err):
+			return result
+		}
+	}
+
+	for _, code := range sortedIntKeys(responses.StatusCodeResponses) {
+		response := responses.StatusCodeResponses[code]
+		resolved, err := s.resolveResponse(response)
+		if err != nil {
+			if callmeOnError(response, err) {
+				continue
+			}
+
+			break
+		}
+
+		result.StatusCodeResponses[code] = resolved
+	}
+
+	return result
+}
+
+// resolveResponse follows $ref to responses, then resolves $ref in header items.
+func (s *Spec) resolveResponse(response spec.Response) (spec.Response, error) {
+	seen := make(map[string]struct{})
+	for response.Ref.String() != "" {
+		key := response.Ref.String()
+		if _, cyclic := seen[key]; cyclic {
+			return response, ErrInvalidRef(key)
+		}
+		seen[key] = struct{}{}
+
+		obj, _, err := response.Ref.GetPointer().Get(s.spec)
+		if err != nil {
+			return response, ErrInvalidRef(key)
+		}
+
+		resolved, ok := obj.(spec.Response)
+		if !ok {
+			return response, ErrInvalidResponseRef(key)
+		}
+
+		response = resolved
+	}
+
+	if len(response.Headers) == 0 {
+		return response, nil
+	}
+
+	headers := make(map[string]spec.Header, len(response.Headers))
+	for name, header := range response.Headers {
+		items, err := s.resolveItems(header.Items)
+		if err != nil {
+			return response, err
+		}
+
+		header.Items = items
+		headers[name] = header
+	}
+	response.Headers = headers
+
+	return response, nil
+}
+
+// resolveItems resolves $ref in simple items, at all nesting levels.
+//
+// NOTE: swagger 2.0 does not support $ref in simple items. However, it is possible to resolve such
+// references when they point to other items or to a simple schema.
+func (s *Spec) resolveItems(items *spec.Items) (*spec.Items, error) {
+	var result *spec.Items
+	// resolving the same $ref twice means that items are infinitely nested
+	seen := make(map[string]struct{})
+
+	for target := &result; items != nil; {
+		resolved := *items
+		for resolved.Ref.String() != "" {
+			key := resolved.Ref.String()
+			if _, cyclic := seen[key]; cyclic {
+				return nil, ErrInvalidRef(key)
+			}
+			seen[key] = struct{}{}
+
+			r, err := s.resolveItemsRef(resolved.Ref)
+			if err != nil {
+				return nil, err
+			}
+			resolved = *r
+		}
+
+		*target = &resolved
+		items = resolved.Items
+		target = &resolved.Items
+	}
+
+	return result, nil
+}
+
+// resolveItemsRef follows one $ref to simple items or to a simple schema.
+func (s *Spec) resolveItemsRef(ref spec.Ref) (*spec.Items, error) {
+	key := ref.String()
+	obj, _, err := ref.GetPointer().Get(s.spec)
+	if err != nil {
+		return nil, ErrInvalidRef(key)
+	}
+
+	var (
+		items *spec.Items
+		ok    bool
+	)
+	switch target := obj.(type) {
+	case *spec.Items:
+		items, ok = target, target != nil
+	case spec.Items:
+		items, ok = &target, true
+	case spec.Schema:
+		items, ok = itemsFromSchema(&target)
+	case *spec.Schema:
+		items, ok = itemsFromSchema(target)
+	}
+	if !ok {
+		return nil, ErrInvalidItemsRef(key)
+	}
+
+	return items, nil
+}
+
+// itemsFromSchema converts a simple schema to simple items.
+func itemsFromSchema(schema *spec.Schema) (*spec.Items, bool) {
+	if len(schema.Type) != 1 || schema.Type[0] == "object" {
+		return nil, false
+	}
+
+	items := &spec.Items{
+		SimpleSchema: spec.SimpleSchema{
+			Type:    schema.Type[0],
+			Format:  schema.Format,
+			Default: schema.Default,
+			Example: schema.Example,
+		},
+		CommonValidations: spec.CommonValidations{
+			Maximum:          schema.Maximum,
+			ExclusiveMaximum: schema.ExclusiveMaximum,
+			Minimum:          schema.Minimum,
+			ExclusiveMinimum: schema.ExclusiveMinimum,
+			MaxLength:        schema.MaxLength,
+			MinLength:        schema.MinLength,
+			Pattern:          schema.Pattern,
+			MaxItems:         schema.MaxItems,
+			MinItems:         schema.MinItems,
+			UniqueItems:      schema.UniqueItems,
+			MultipleOf:       schema.MultipleOf,
+			Enum:             schema.Enum,
+		},
+		VendorExtensible: schema.VendorExtensible,
+	}
+
+	if schema.Type[0] != "array" {
+		return items, true
+	}
+
+	if schema.Items == nil || schema.Items.Schema == nil {
+		return nil, false
+	}
+
+	nested, ok := itemsFromSchema(schema.Items.Schema)
+	if !ok {
+		return nil, false
+	}
+	items.Items = nested
+
+	return items, true
+}
+
+func sortedIntKeys[T any](m map[int]T) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	return keys
+}
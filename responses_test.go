@@ -0,0 +1,161 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-openapi/analysis/internal/antest"
+	"github.com/go-openapi/spec"
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestAnalyzer_ResponsesFor(t *testing.T) {
+	t.Parallel()
+
+	doc := antest.LoadOrFail(t, filepath.Join("fixtures", "references.yml"))
+	doc.Paths.Paths["/some/where/{id}"].Get.ID = someOperation
+	an := New(doc)
+
+	t.Run("should resolve responses", func(t *testing.T) {
+		responses := an.ResponsesFor("get", "/some/where/{id}")
+		require.NotNil(t, responses)
+		require.NotNil(t, responses.Default)
+		require.Len(t, responses.StatusCodeResponses, 2)
+
+		notFound := responses.StatusCodeResponses[404]
+		assert.Empty(t, notFound.Ref.String())
+		require.NotNil(t, notFound.Schema)
+		assert.Equal(t, "#/definitions/error", notFound.Schema.Ref.String())
+
+		header, ok := responses.Default.Headers["x-array-header"]
+		require.True(t, ok)
+		require.NotNil(t, header.Items)
+		assert.Empty(t, header.Items.Ref.String())
+		assert.Equal(t, "string", header.Items.Type)
+
+		// the original document is left unchanged
+		original := doc.Paths.Paths["/some/where/{id}"].Get.Responses
+		originalNotFound := original.StatusCodeResponses[404]
+		assert.Equal(t, "#/responses/notFound", originalNotFound.Ref.String())
+		assert.Equal(t, "#/definitions/named", original.Default.Headers["x-array-header"].Items.Ref.String())
+
+		assert.Nil(t, an.ResponsesFor("delete", "/some/where/{id}"))
+	})
+
+	t.Run("should resolve response by status code", func(t *testing.T) {
+		response, ok := an.ResponseFor(someOperation, 404)
+		require.True(t, ok)
+		require.NotNil(t, response.Schema)
+		assert.Equal(t, "#/definitions/error", response.Schema.Ref.String())
+
+		response, ok = an.ResponseFor(someOperation, 500)
+		require.True(t, ok, "expected a fallback to the default response")
+		assert.Contains(t, response.Headers, "x-array-header")
+
+		_, ok = an.ResponseFor(anotherOperation, 200)
+		assert.False(t, ok)
+	})
+
+	t.Run("should report invalid references", func(t *testing.T) {
+		invalid := antest.LoadOrFail(t, filepath.Join("fixtures", "references.yml"))
+		responses := invalid.Paths.Paths["/some/where/{id}"].Get.Responses
+		responses.StatusCodeResponses[400] = *spec.ResponseRef("#/definitions/tag")
+		responses.StatusCodeResponses[401] = *spec.ResponseRef("#/responses/nowhere")
+		header := responses.Default.Headers["x-array-header"]
+		header.Items.Ref = spec.MustCreateRef("#/definitions/tag")
+		responses.Default.Headers["x-array-header"] = header
+		an := New(invalid)
+
+		var errs []string
+		resolved := an.SafeResponsesFor("get", "/some/where/{id}", func(_ spec.Response, err error) bool {
+			errs = append(errs, err.Error())
+
+			return true
+		})
+		require.NotNil(t, resolved)
+		assert.Nil(t, resolved.Default)
+		assert.Len(t, resolved.StatusCodeResponses, 2)
+		require.Len(t, errs, 3)
+
+		all := strings.Join(errs, ",")
+		assert.Contains(t, all, `resolved reference is not a response: "#/definitions/tag"`)
+		assert.Contains(t, all, `invalid reference: "#/responses/nowhere"`)
+		assert.Contains(t, all, `resolved reference is not a simple item: "#/definitions/tag"`)
+
+		errs = errs[:0]
+		_ = an.SafeResponsesFor("get", "/some/where/{id}", func(_ spec.Response, err error) bool {
+			errs = append(errs, err.Error())
+
+			return false
+		})
+		assert.Len(t, errs, 1)
+
+		assert.Panics(t, func() {
+			_ = an.ResponsesFor("get", "/some/where/{id}")
+		})
+	})
+
+	t.Run("should not fall back to the default response after an error", func(t *testing.T) {
+		invalid := antest.LoadOrFail(t, filepath.Join("fixtures", "references.yml"))
+		invalid.Paths.Paths["/some/where/{id}"].Get.ID = someOperation
+		invalid.Paths.Paths["/some/where/{id}"].Get.Responses.StatusCodeResponses[401] = *spec.ResponseRef("#/responses/nowhere")
+		an := New(invalid)
+
+		var errs []string
+		_, ok := an.SafeResponseFor(someOperation, 401, func(_ spec.Response, err error) bool {
+			errs = append(errs, err.Error())
+
+			return true
+		})
+		assert.False(t, ok)
+		assert.Len(t, errs, 1)
+
+		_, ok = an.SafeResponseFor(someOperation, 500, func(_ spec.Response, _ error) bool { return true })
+		assert.True(t, ok, "expected a fallback to the default response")
+	})
+
+	t.Run("should resolve chained $ref in header items", func(t *testing.T) {
+		chained := antest.LoadOrFail(t, filepath.Join("fixtures", "references.yml"))
+		chained.Parameters["arrayParam"] = *spec.QueryParam("tags").CollectionOf(
+			&spec.Items{Refable: spec.Refable{Ref: spec.MustCreateRef("#/definitions/named")}}, "csv")
+		responses := chained.Paths.Paths["/some/where/{id}"].Get.Responses
+		header := responses.Default.Headers["x-array-header"]
+		header.Items.Ref = spec.MustCreateRef("#/parameters/arrayParam/items")
+		responses.Default.Headers["x-array-header"] = header
+
+		resolved := New(chained).ResponsesFor("get", "/some/where/{id}")
+		require.NotNil(t, resolved)
+		require.NotNil(t, resolved.Default)
+		items := resolved.Default.Headers["x-array-header"].Items
+		require.NotNil(t, items)
+		assert.Empty(t, items.Ref.String())
+		assert.Equal(t, "string", items.Type)
+	})
+
+	t.Run("should report cyclic $ref in header items", func(t *testing.T) {
+		cyclic := antest.LoadOrFail(t, filepath.Join("fixtures", "references.yml"))
+		nested := spec.NewItems().Typed("array", "")
+		nested.Items = &spec.Items{Refable: spec.Refable{Ref: spec.MustCreateRef("#/parameters/arrayParam/items")}}
+		cyclic.Parameters["arrayParam"] = *spec.QueryParam("tags").CollectionOf(nested, "csv")
+		responses := cyclic.Paths.Paths["/some/where/{id}"].Get.Responses
+		header := responses.Default.Headers["x-array-header"]
+		header.Items.Ref = spec.MustCreateRef("#/parameters/arrayParam/items")
+		responses.Default.Headers["x-array-header"] = header
+
+		var errs []string
+		resolved := New(cyclic).SafeResponsesFor("get", "/some/where/{id}", func(_ spec.Response, err error) bool {
+			errs = append(errs, err.Error())
+
+			return true
+		})
+		require.NotNil(t, resolved)
+		assert.Nil(t, resolved.Default)
+		require.Len(t, errs, 1)
+		assert.StringContainsT(t, errs[0], `invalid reference: "#/parameters/arrayParam/items"`)
+	})
+}
@@ -0,0 +1,181 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"fmt"
+
+	"github.com/go-openapi/spec"
+)
+
+// SecurityReport aggregates the security requirements of all the operations in a spec.
+//
+// Operations are reported as "METHOD path" (e.g. "GET /pets/{id}"), in the order of [Spec.OperationViews].
+type SecurityReport struct {
+	// Schemes lists the security schemes either declared in the security definitions or required
+	// by some operation, sorted by name.
+	Schemes []SchemeUsage
+
+	// Unauthenticated lists the operations which may be called without any credentials, i.e. operations
+	// without any effective security requirement, or which accept an empty security requirement.
+	Unauthenticated []string
+}
+
+// SchemeUsage describes which operations require a security scheme.
+type SchemeUsage struct {
+	Name string
+
+	// Declared is false whenever the scheme is required by some operation, but is missing from the
+	// security definitions of the spec.
+	Declared bool
+
+	// Operations which require this scheme.
+	Operations []string
+
+	// Scopes lists the scopes either declared by the scheme or required by some operation, sorted by name.
+	Scopes []ScopeUsage
+}
+
+// ScopeUsage describes which operations require a scope of a security scheme.
+type ScopeUsage struct {
+	Name string
+
+	// Declared is false whenever the scope is required by some operation, but is missing from the
+	// scopes declared by the security scheme. Only OAuth2 schemes declare scopes.
+	Declared bool
+
+	// Operations which require this scope.
+	Operations []string
+}
+
+// UnusedSchemes returns the names of the security schemes which are declared but never required.
+func (r SecurityReport) UnusedSchemes() []string {
+	var names []string
+	for _, scheme := range r.Schemes {
+		if len(scheme.Operations) == 0 {
+			names = append(names, scheme.Name)
+		}
+	}
+
+	return names
+}
+
+// UndeclaredSchemes returns the names of the security schemes which are required but never declared.
+func (r SecurityReport) UndeclaredSchemes() []string {
+	var names []string
+	for _, scheme := range r.Schemes {
+		if !scheme.Declared {
+			names = append(names, scheme.Name)
+		}
+	}
+
+	return names
+}
+
+// UnusedScopes returns the scopes which are declared by the scheme, but never required.
+func (u SchemeUsage) UnusedScopes() []string {
+	var names []string
+	for _, scope := range u.Scopes {
+		if len(scope.Operations) == 0 {
+			names = append(names, scope.Name)
+		}
+	}
+
+	return names
+}
+
+// UndeclaredScopes returns the scopes which are required, but not declared by the scheme.
+func (u SchemeUsage) UndeclaredScopes() []string {
+	var names []string
+	for _, scope := range u.Scopes {
+		if !scope.Declared {
+			names = append(names, scope.Name)
+		}
+	}
+
+	return names
+}
+
+// SecurityReport analyzes the effective security requirements of all operations.
+//
+// It lists, for every security scheme and scope, the operations which require it and
+// the operations which are effectively unauthenticated.
+func (s *Spec) SecurityReport() SecurityReport {
+	schemes := make(map[string]*SchemeUsage, len(s.spec.SecurityDefinitions))
+	scopes := make(map[string]map[string]*ScopeUsage, len(s.spec.SecurityDefinitions))
+
+	usageOf := func(name string) (*SchemeUsage, map[string]*ScopeUsage) {
+		if scheme, ok := schemes[name]; ok {
+			return scheme, scopes[name]
+		}
+
+		scheme := &SchemeUsage{Name: name}
+		schemeScopes := make(map[string]*ScopeUsage, allocSmallMap)
+		if definition, ok := s.spec.SecurityDefinitions[name]; ok && definition != nil {
+			scheme.Declared = true
+			for scope := range definition.Scopes {
+				schemeScopes[scope] = &ScopeUsage{Name: scope, Declared: true}
+			}
+		}
+		schemes[name] = scheme
+		scopes[name] = schemeScopes
+
+		return scheme, schemeScopes
+	}
+
+	for name := range s.spec.SecurityDefinitions {
+		_, _ = usageOf(name)
+	}
+
+	var report SecurityReport
+	for view := range s.SafeOperationViews(func(spec.Parameter, error) bool { return true }) {
+		operation := fmt.Sprintf("%s %s", view.Method, view.Path)
+		if len(view.Security) == 0 {
+			report.Unauthenticated = append(report.Unauthenticated, operation)
+
+			continue
+		}
+
+		for _, requirements := range view.Security {
+			for _, requirement := range requirements {
+				if requirement.Name == "" {
+					// an empty requirement makes security optional
+					report.Unauthenticated = appendOnce(report.Unauthenticated, operation)
+
+					continue
+				}
+
+				scheme, schemeScopes := usageOf(requirement.Name)
+				scheme.Operations = appendOnce(scheme.Operations, operation)
+				for _, name := range requirement.Scopes {
+					scope, ok := schemeScopes[name]
+					if !ok {
+						scope = &ScopeUsage{Name: name}
+						schemeScopes[name] = scope
+					}
+					scope.Operations = appendOnce(scope.Operations, operation)
+				}
+			}
+		}
+	}
+
+	for _, name := range sortedKeys(schemes) {
+		scheme := schemes[name]
+		for _, scope := range sortedKeys(scopes[name]) {
+			scheme.Scopes = append(scheme.Scopes, *scopes[name][scope])
+		}
+		report.Schemes = append(report.Schemes, *scheme)
+	}
+
+	return report
+}
+
+// appendOnce appends a value to a slice, unless the slice already ends with this value.
+func appendOnce(values []string, value string) []string {
+	if len(values) > 0 && values[len(values)-1] == value {
+		return values
+	}
+
+	return append(values, value)
+}
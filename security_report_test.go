@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/analysis/internal/antest"
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestAnalyzer_SecurityReport(t *testing.T) {
+	t.Parallel()
+
+	doc := antest.LoadOrFail(t, filepath.Join("fixtures", "security.yml"))
+	report := New(doc).SecurityReport()
+
+	assert.Equal(t, []string{"GET /health", "GET /pets/{id}"}, report.Unauthenticated)
+	assert.Equal(t, []string{"basic"}, report.UnusedSchemes())
+	assert.Equal(t, []string{"legacy"}, report.UndeclaredSchemes())
+
+	require.Len(t, report.Schemes, 4)
+	apiKey := report.Schemes[0]
+	assert.Equal(t, SchemeUsage{Name: "apiKey", Declared: true, Operations: []string{"GET /pets"}}, apiKey)
+
+	legacy := report.Schemes[2]
+	assert.Equal(t, "legacy", legacy.Name)
+	assert.Equal(t, []string{"anything"}, legacy.UndeclaredScopes())
+
+	oauth := report.Schemes[3]
+	assert.Equal(t, "petstoreAuth", oauth.Name)
+	assert.Equal(t, []string{"POST /pets", "GET /pets/{id}", "DELETE /pets/{id}"}, oauth.Operations)
+	assert.Equal(t, []string{"admin"}, oauth.UnusedScopes())
+	assert.Equal(t, []string{"delete:pets"}, oauth.UndeclaredScopes())
+	assert.Equal(t, []ScopeUsage{
+		{Name: "admin", Declared: true},
+		{Name: "delete:pets", Operations: []string{"GET /pets/{id}"}},
+		{Name: "read:pets", Declared: true, Operations: []string{"POST /pets", "GET /pets/{id}"}},
+		{Name: "write:pets", Declared: true, Operations: []string{"POST /pets", "DELETE /pets/{id}"}},
+	}, oauth.Scopes)
+}
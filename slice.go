@@ -0,0 +1,242 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"maps"
+	slashpath "path"
+	"slices"
+	"strings"
+
+	"github.com/go-openapi/jsonpointer"
+	"github.com/go-openapi/spec"
+)
+
+// OperationFilter selects operations when slicing a spec with [Spec.Slice].
+type OperationFilter func(OperationView) bool
+
+// FilterByTags selects operations with any of the given tags.
+func FilterByTags(tags ...string) OperationFilter {
+	return func(view OperationView) bool {
+		for _, tag := range view.Tags {
+			if slices.Contains(tags, tag) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// FilterByOperationIDs selects operations with any of the given operation ids.
+func FilterByOperationIDs(operationIDs ...string) OperationFilter {
+	return func(view OperationView) bool {
+		return view.ID != "" && slices.Contains(operationIDs, view.ID)
+	}
+}
+
+// FilterByPathPrefix selects operations with a path starting with the given prefix, e.g. "/pets".
+func FilterByPathPrefix(prefix string) OperationFilter {
+	return func(view OperationView) bool {
+		return strings.HasPrefix(view.Path, prefix)
+	}
+}
+
+// Slice builds a new swagger spec containing only the operations selected by the filter.
+//
+// The sliced spec retains exactly the definitions, shared parameters and shared responses that the selected
+// operations transitively refer to, the security definitions they require and the tags they use.
+// Global security requirements are kept only when some selected operation inherits them.
+// Everything else is pruned. Top-level properties such as info, host, base path or vendor extensions are kept.
+//
+// Remote $ref are left untouched: the spec should be flattened first to slice them as well.
+//
+// NOTE: the sliced spec shares operations, schemas and other objects with the analyzed spec. Clone these
+// objects before mutating them.
+func (s *Spec) Slice(filter OperationFilter) *spec.Swagger {
+	sliced := &spec.Swagger{
+		VendorExtensible: spec.VendorExtensible{Extensions: maps.Clone(s.spec.Extensions)},
+		SwaggerProps:     s.spec.SwaggerProps,
+	}
+	sliced.Paths = &spec.Paths{Paths: make(map[string]spec.PathItem)}
+	if s.spec.Paths != nil {
+		sliced.Paths.Extensions = maps.Clone(s.spec.Paths.Extensions)
+	}
+	sliced.Definitions = nil
+	sliced.Parameters = nil
+	sliced.Responses = nil
+	sliced.Security = nil
+	sliced.SecurityDefinitions = nil
+	sliced.Tags = nil
+
+	var roots []string
+	schemes := make(map[string]struct{}, allocSmallMap)
+	tags := make(map[string]struct{}, allocSmallMap)
+
+	for view := range s.SafeOperationViews(func(spec.Parameter, error) bool { return true }) {
+		if !filter(view) {
+			continue
+		}
+
+		pathItem, ok := sliced.Paths.Paths[view.Path]
+		if !ok {
+			original := s.spec.Paths.Paths[view.Path]
+			pathItem = spec.PathItem{
+				Refable:          original.Refable,
+				VendorExtensible: original.VendorExtensible,
+				PathItemProps:    spec.PathItemProps{Parameters: original.Parameters},
+			}
+			roots = append(roots, "#"+slashpath.Join("/paths", jsonpointer.Escape(view.Path), "parameters"))
+		}
+		setOperationForMethod(&pathItem, view.Method, view.Operation)
+		sliced.Paths.Paths[view.Path] = pathItem
+		roots = append(roots, "#"+slashpath.Join("/paths", jsonpointer.Escape(view.Path), strings.ToLower(view.Method)))
+
+		if view.Operation.Security == nil {
+			// the effective security of the operation is the global one
+			sliced.Security = s.spec.Security
+		}
+
+		for _, requirements := range view.Security {
+			for _, requirement := range requirements {
+				if requirement.Name != "" {
+					schemes[requirement.Name] = struct{}{}
+				}
+			}
+		}
+
+		for _, tag := range view.Tags {
+			tags[tag] = struct{}{}
+		}
+	}
+
+	for _, component := range s.componentsReachableFrom(roots) {
+		parts := pointerParts(component)
+		name := parts[1]
+
+		switch parts[0] {
+		case "definitions":
+			if sliced.Definitions == nil {
+				sliced.Definitions = make(spec.Definitions)
+			}
+			sliced.Definitions[name] = s.spec.Definitions[name]
+		case "parameters":
+			if sliced.Parameters == nil {
+				sliced.Parameters = make(map[string]spec.Parameter)
+			}
+			sliced.Parameters[name] = s.spec.Parameters[name]
+		case "responses":
+			if sliced.Responses == nil {
+				sliced.Responses = make(map[string]spec.Response)
+			}
+			sliced.Responses[name] = s.spec.Responses[name]
+		}
+	}
+
+	for name := range schemes {
+		definition, ok := s.spec.SecurityDefinitions[name]
+		if !ok {
+			continue
+		}
+
+		if sliced.SecurityDefinitions == nil {
+			sliced.SecurityDefinitions = make(spec.SecurityDefinitions)
+		}
+		sliced.SecurityDefinitions[name] = definition
+	}
+
+	for _, tag := range s.spec.Tags {
+		if _, ok := tags[tag.Name]; ok {
+			sliced.Tags = append(sliced.Tags, tag)
+		}
+	}
+
+	return sliced
+}
+
+// componentsReachableFrom returns the definitions, shared parameters and shared responses which are
+// transitively referred to by $ref located under the root keys.
+//
+// Components are returned as sorted JSON pointers, e.g. "#/definitions/Pet".
+func (s *Spec) componentsReachableFrom(roots []string) []string {
+	components := make(map[string]struct{}, allocMediumMap)
+	pending := slices.Clone(roots)
+
+	for len(pending) > 0 {
+		root := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		for key, ref := range s.references.allRefs {
+			if !isUnder(key, root) {
+				continue
+			}
+
+			component, ok := s.componentOf(ref.String())
+			if !ok {
+				continue
+			}
+
+			if _, known := components[component]; known {
+				continue
+			}
+
+			components[component] = struct{}{}
+			pending = append(pending, component)
+		}
+	}
+
+	return s.sortedStructMapKeys(components)
+}
+
+// componentOf yields the definition, shared parameter or shared response enclosing a local JSON pointer.
+func (s *Spec) componentOf(pointer string) (string, bool) {
+	if !strings.HasPrefix(pointer, "#/") {
+		return "", false
+	}
+
+	parts := pointerParts(pointer)
+	if len(parts) < 2 { //nolint:mnd // a component requires at least 2 parts
+		return "", false
+	}
+
+	var found bool
+	switch parts[0] {
+	case "definitions":
+		_, found = s.spec.Definitions[parts[1]]
+	case "parameters":
+		_, found = s.spec.Parameters[parts[1]]
+	case "responses":
+		_, found = s.spec.Responses[parts[1]]
+	}
+
+	if !found {
+		return "", false
+	}
+
+	return "#" + slashpath.Join("/", parts[0], jsonpointer.Escape(parts[1])), true
+}
+
+func setOperationForMethod(pathItem *spec.PathItem, method string, op *spec.Operation) {
+	switch method {
+	case "GET":
+		pathItem.Get = op
+	case "PUT":
+		pathItem.Put = op
+	case "POST":
+		pathItem.Post = op
+	case "PATCH":
+		pathItem.Patch = op
+	case "DELETE":
+		pathItem.Delete = op
+	case "HEAD":
+		pathItem.Head = op
+	case "OPTIONS":
+		pathItem.Options = op
+	}
+}
+
+// isUnder is true when key is equal to or nested under root.
+func isUnder(key, root string) bool {
+	return key == root || strings.HasPrefix(key, root+"/")
+}
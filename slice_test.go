@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/analysis/internal/antest"
+	"github.com/go-openapi/spec"
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestSlice(t *testing.T) {
+	t.Parallel()
+
+	load := func(t *testing.T) *Spec {
+		doc := antest.LoadOrFail(t, filepath.Join("fixtures", "widget-crud.yml"))
+		doc.Paths.Paths["/widgets"].Post.Tags = []string{"writers"}
+		doc.Paths.Paths["/widgets/{widgetid}"].Delete.Tags = []string{"writers", "admin"}
+		doc.Paths.Paths["/widgets/{widgetid}"].Delete.Security = []map[string][]string{{"myRoles": {"sellers"}}}
+		doc.Tags = append(doc.Tags, spec.NewTag("writers", "", nil), spec.NewTag("admin", "", nil))
+		doc.Security = nil
+
+		return New(doc)
+	}
+
+	t.Run("should slice by operation id", func(t *testing.T) {
+		an := load(t)
+		sliced := an.Slice(FilterByOperationIDs("get"))
+
+		require.Len(t, sliced.Paths.Paths, 1)
+		pathItem := sliced.Paths.Paths["/widgets/{widgetid}"]
+		assert.NotNil(t, pathItem.Get)
+		assert.Nil(t, pathItem.Delete)
+		assert.Nil(t, pathItem.Post)
+
+		assert.Equal(t, []string{"error", "widget"}, sortedKeys(sliced.Definitions))
+		assert.Equal(t, []string{"widgetid"}, sortedKeys(sliced.Parameters))
+		assert.Equal(t, []string{"401", "404"}, sortedKeys(sliced.Responses))
+		assert.Empty(t, sliced.SecurityDefinitions)
+		assert.Empty(t, sliced.Tags)
+
+		assert.Equal(t, an.spec.Info, sliced.Info)
+		assert.Equal(t, an.spec.BasePath, sliced.BasePath)
+
+		// the original spec is left unchanged
+		assert.Len(t, an.spec.Definitions, 4)
+		assert.Len(t, an.spec.Paths.Paths, 3)
+	})
+
+	t.Run("should slice by tags", func(t *testing.T) {
+		an := load(t)
+		sliced := an.Slice(FilterByTags("writers"))
+
+		assert.Equal(t, []string{"/widgets", "/widgets/{widgetid}"}, sortedKeys(sliced.Paths.Paths))
+		assert.NotNil(t, sliced.Paths.Paths["/widgets"].Post)
+		assert.NotNil(t, sliced.Paths.Paths["/widgets/{widgetid}"].Delete)
+		assert.Nil(t, sliced.Paths.Paths["/widgets/{widgetid}"].Get)
+
+		assert.Equal(t, []string{"error", "widget", "widgetId"}, sortedKeys(sliced.Definitions))
+		assert.Empty(t, sliced.Parameters)
+		assert.Empty(t, sliced.Responses)
+		assert.Equal(t, []string{"myRoles"}, sortedKeys(sliced.SecurityDefinitions))
+
+		require.Len(t, sliced.Tags, 2)
+		assert.Equal(t, "writers", sliced.Tags[0].Name)
+		assert.Equal(t, "admin", sliced.Tags[1].Name)
+	})
+
+	t.Run("should slice by path prefix, with global security", func(t *testing.T) {
+		doc := antest.LoadOrFail(t, filepath.Join("fixtures", "widget-crud.yml"))
+		sliced := New(doc).Slice(FilterByPathPrefix("/common"))
+
+		assert.Equal(t, []string{"/common"}, sortedKeys(sliced.Paths.Paths))
+		assert.Equal(t, []string{"widget"}, sortedKeys(sliced.Definitions))
+		assert.Equal(t, []string{"myBasicAuth", "myPrimaryAPIKey", "myRoles"}, sortedKeys(sliced.SecurityDefinitions))
+		assert.Equal(t, doc.Security, sliced.Security)
+	})
+
+	t.Run("should drop global security when no selected operation inherits it", func(t *testing.T) {
+		doc := antest.LoadOrFail(t, filepath.Join("fixtures", "widget-crud.yml"))
+		require.NotEmpty(t, doc.Security)
+		doc.Paths.Paths["/widgets/{widgetid}"].Delete.Tags = []string{"admin"}
+		doc.Paths.Paths["/widgets/{widgetid}"].Delete.Security = []map[string][]string{{"myRoles": {"sellers"}}}
+		an := New(doc)
+
+		sliced := an.Slice(FilterByTags("admin"))
+		assert.Equal(t, []string{"/widgets/{widgetid}"}, sortedKeys(sliced.Paths.Paths))
+		assert.Equal(t, []string{"myRoles"}, sortedKeys(sliced.SecurityDefinitions))
+		assert.Nil(t, sliced.Security)
+
+		nothing := an.Slice(func(OperationView) bool { return false })
+		assert.Empty(t, nothing.SecurityDefinitions)
+		assert.Nil(t, nothing.Security)
+	})
+
+	t.Run("should follow transitive references", func(t *testing.T) {
+		doc := antest.LoadOrFail(t, filepath.Join("fixtures", "references.yml"))
+		sliced := New(doc).Slice(func(OperationView) bool { return true })
+
+		assert.Equal(t, []string{"error", "named", "record", "tag"}, sortedKeys(sliced.Definitions))
+		assert.Equal(t, []string{"idParam", "limitParam"}, sortedKeys(sliced.Parameters))
+		assert.Equal(t, []string{"notFound"}, sortedKeys(sliced.Responses))
+	})
+
+	t.Run("should slice nothing", func(t *testing.T) {
+		an := load(t)
+		sliced := an.Slice(func(OperationView) bool { return false })
+
+		assert.Empty(t, sliced.Paths.Paths)
+		assert.Nil(t, sliced.Definitions)
+		assert.Nil(t, sliced.Parameters)
+		assert.Nil(t, sliced.Responses)
+	})
+}
@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import "github.com/go-openapi/spec"
+
+// Snapshot is an immutable copy of an analyzed spec, safe for concurrent use by multiple goroutines.
+//
+// A snapshot owns a deep copy of the swagger document: mutating the original spec (e.g. by flattening it)
+// does not affect the snapshot.
+//
+// All the query methods of [Spec] are available from a snapshot. Methods that update the analysis panic
+// with [ErrImmutableSpec] (e.g. [Spec.Reanalyze]) or return this error (e.g. [Flatten]).
+type Snapshot struct {
+	*Spec
+}
+
+// Snapshot takes an immutable copy of the analyzed spec.
+//
+// The swagger document is deep-copied with a structural clone, then analyzed again.
+func (s *Spec) Snapshot() *Snapshot {
+	a := s.analyzeCopy(cloneSwagger(s.spec), true)
+
+	// lazily computed indices are computed upfront, so readers never update the snapshot
+	_ = a.schemaUsages()
+
+	return &Snapshot{Spec: a}
+}
+
+// Swagger returns the document owned by the snapshot.
+//
+// The returned document is shared by all readers: it must not be mutated.
+func (s *Snapshot) Swagger() *spec.Swagger {
+	return s.spec
+}
+
+// mustBeMutable panics whenever the analyzed spec is an immutable snapshot.
+func (s *Spec) mustBeMutable() {
+	if s.readOnly {
+		panic(ErrImmutableSpec)
+	}
+}
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/go-openapi/analysis/internal/antest"
+	"github.com/go-openapi/spec"
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestAnalyzer_ConcurrentReads(t *testing.T) {
+	t.Parallel()
+
+	doc := antest.LoadOrFail(t, filepath.Join("fixtures", "usage.yml"))
+	an := New(doc)
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			readAll(t, an)
+		})
+	}
+	wg.Wait()
+}
+
+func TestSnapshot(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should read a snapshot while flattening the original spec", func(t *testing.T) {
+		bp := filepath.Join("fixtures", "flatten.yml")
+		doc := antest.LoadOrFail(t, bp)
+		an := New(doc)
+
+		snapshot := an.Snapshot()
+		expected := an.AllReferences()
+
+		var wg sync.WaitGroup
+		for range 8 {
+			wg.Go(func() {
+				readAll(t, snapshot.Spec)
+			})
+		}
+
+		wg.Go(func() {
+			assert.NoError(t, Flatten(FlattenOpts{
+				Spec: an, BasePath: bp, Minimal: false, RemoveUnused: true,
+			}))
+		})
+		wg.Wait()
+
+		assert.ElementsMatch(t, expected, snapshot.AllReferences())
+		assert.NotEqual(t, sortedKeys(an.spec.Definitions), sortedKeys(snapshot.Swagger().Definitions))
+	})
+
+	t.Run("should refuse to update a snapshot", func(t *testing.T) {
+		doc := antest.LoadOrFail(t, filepath.Join("fixtures", "usage.yml"))
+		snapshot := New(doc).Snapshot()
+
+		assert.PanicsWithValue(t, ErrImmutableSpec, func() {
+			snapshot.Reanalyze("#/definitions/pet")
+		})
+		require.ErrorIs(t, Flatten(FlattenOpts{Spec: snapshot.Spec}), ErrImmutableSpec)
+	})
+}
+
+func readAll(t testing.TB, an *Spec) {
+	ignoreParams := func(spec.Parameter, error) bool { return true }
+	ignoreResponses := func(spec.Response, error) bool { return true }
+
+	for view := range an.SafeOperationViews(ignoreParams) {
+		_ = an.SecurityRequirementsFor(view.Operation)
+		_ = an.SecurityDefinitionsFor(view.Operation)
+		_ = an.SafeParamsFor(view.Method, view.Path, ignoreParams)
+		_ = an.SafeResponsesFor(view.Method, view.Path, ignoreResponses)
+		_ = an.SafeParametersFor(view.ID, ignoreParams)
+	}
+
+	_ = an.AllDefinitions()
+	_ = an.AllRefs()
+	_ = an.AllPatterns()
+	_ = an.AllEnums()
+	_ = an.AllExtensions()
+	_ = an.DefinitionUsages()
+	_ = an.DefinitionGraph()
+	_ = an.RouteConflicts()
+	_ = an.ReferrersOf("#/definitions/pet")
+	assert.NotEmpty(t, an.OperationIDs())
+}
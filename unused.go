@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	slashpath "path"
+	"strconv"
+
+	"github.com/go-openapi/jsonpointer"
+)
+
+// UnusedReason explains why a component is reported as unused.
+type UnusedReason string
+
+const (
+	// UnusedNeverReferenced denotes a component which is not referenced anywhere in the spec.
+	UnusedNeverReferenced UnusedReason = "never-referenced"
+
+	// UnusedOnlyReferencedByUnused denotes a component which is only referenced by other unused components.
+	UnusedOnlyReferencedByUnused UnusedReason = "only-referenced-by-unused"
+)
+
+// UnusedComponent describes a component which is not used by any operation.
+type UnusedComponent struct {
+	// Pointer to the component, e.g. "#/definitions/Pet" or "#/tags/0".
+	Pointer string
+	Name    string
+	Reason  UnusedReason
+
+	// ReferencedBy lists the locations of the $ref to this component, for components which are
+	// only referenced by other unused components.
+	ReferencedBy []string
+}
+
+// UnusedReport lists the components of a spec which are not used by any operation.
+//
+// Components are sorted by name, tags are listed in the order of their declaration.
+type UnusedReport struct {
+	Definitions         []UnusedComponent
+	Parameters          []UnusedComponent
+	Responses           []UnusedComponent
+	SecurityDefinitions []UnusedComponent
+	Tags                []UnusedComponent
+}
+
+// IsEmpty is true when no unused component is reported.
+func (r UnusedReport) IsEmpty() bool {
+	return len(r.Definitions) == 0 && len(r.Parameters) == 0 && len(r.Responses) == 0 &&
+		len(r.SecurityDefinitions) == 0 && len(r.Tags) == 0
+}
+
+// UnusedReport lists the definitions, shared parameters, shared responses, security definitions
+// and tags which are not used, directly or transitively, by any operation.
+//
+// Unlike the RemoveUnused option of [Flatten], the spec is left unchanged.
+func (s *Spec) UnusedReport() UnusedReport {
+	used := make(map[string]struct{}, allocMediumMap)
+	for _, component := range s.componentsReachableFrom([]string{"#/paths"}) {
+		used[component] = struct{}{}
+	}
+
+	var report UnusedReport
+	for _, name := range sortedKeys(s.spec.Definitions) {
+		report.Definitions = s.appendUnused(report.Definitions, used, "/definitions", name)
+	}
+
+	for _, name := range sortedKeys(s.spec.Parameters) {
+		report.Parameters = s.appendUnused(report.Parameters, used, "/parameters", name)
+	}
+
+	for _, name := range sortedKeys(s.spec.Responses) {
+		report.Responses = s.appendUnused(report.Responses, used, "/responses", name)
+	}
+
+	for _, name := range s.SecurityReport().UnusedSchemes() {
+		report.SecurityDefinitions = append(report.SecurityDefinitions, UnusedComponent{
+			Pointer: "#" + slashpath.Join("/securityDefinitions", jsonpointer.Escape(name)),
+			Name:    name,
+			Reason:  UnusedNeverReferenced,
+		})
+	}
+
+	tags := make(map[string]struct{}, len(s.spec.Tags))
+	for _, pathItem := range s.operations {
+		for _, op := range pathItem {
+			for _, tag := range op.Tags {
+				tags[tag] = struct{}{}
+			}
+		}
+	}
+
+	for i, tag := range s.spec.Tags {
+		if _, ok := tags[tag.Name]; ok {
+			continue
+		}
+
+		report.Tags = append(report.Tags, UnusedComponent{
+			Pointer: "#" + slashpath.Join("/tags", strconv.Itoa(i)),
+			Name:    tag.Name,
+			Reason:  UnusedNeverReferenced,
+		})
+	}
+
+	return report
+}
+
+func (s *Spec) appendUnused(unused []UnusedComponent, used map[string]struct{}, section, name string) []UnusedComponent {
+	pointer := "#" + slashpath.Join(section, jsonpointer.Escape(name))
+	if _, ok := used[pointer]; ok {
+		return unused
+	}
+
+	var referrers []string
+	for _, key := range s.ReferrersOf(pointer) {
+		if !isUnder(key, pointer) {
+			// self-references do not count
+			referrers = append(referrers, key)
+		}
+	}
+
+	component := UnusedComponent{Pointer: pointer, Name: name, Reason: UnusedNeverReferenced}
+	if len(referrers) > 0 {
+		component.Reason = UnusedOnlyReferencedByUnused
+		component.ReferencedBy = referrers
+	}
+
+	return append(unused, component)
+}
@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/analysis/internal/antest"
+	"github.com/go-openapi/spec"
+	"github.com/go-openapi/testify/v2/assert"
+)
+
+func TestAnalyzer_UnusedReport(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should report unused components", func(t *testing.T) {
+		doc := antest.LoadOrFail(t, filepath.Join("fixtures", "usage.yml"))
+		doc.Definitions["orphan"] = *new(spec.Schema).Typed("object", "").
+			SetProperty("thing", *spec.RefSchema("#/definitions/unused"))
+		doc.Definitions["selfish"] = *new(spec.Schema).Typed("object", "").
+			SetProperty("next", *spec.RefSchema("#/definitions/selfish"))
+		doc.Parameters["unusedParam"] = *spec.QueryParam("limit").Typed("integer", "")
+		doc.Responses["unusedResponse"] = *spec.NewResponse().WithSchema(spec.RefSchema("#/definitions/orphan"))
+		doc.SecurityDefinitions = spec.SecurityDefinitions{"apiKey": spec.APIKeyAuth("api_key", "query"), "basic": spec.BasicAuth()}
+		doc.Security = []map[string][]string{{"apiKey": {}}}
+		doc.Tags = []spec.Tag{spec.NewTag("pets", "", nil), spec.NewTag("store", "", nil)}
+		doc.Paths.Paths["/pets"].Post.Tags = []string{"pets"}
+		snapshot := antest.AsJSON(t, doc)
+
+		report := New(doc).UnusedReport()
+		assert.False(t, report.IsEmpty())
+
+		assert.Equal(t, []UnusedComponent{
+			{
+				Pointer: "#/definitions/orphan", Name: "orphan", Reason: UnusedOnlyReferencedByUnused,
+				ReferencedBy: []string{"#/responses/unusedResponse/schema"},
+			},
+			{Pointer: "#/definitions/selfish", Name: "selfish", Reason: UnusedNeverReferenced},
+			{
+				Pointer: "#/definitions/unused", Name: "unused", Reason: UnusedOnlyReferencedByUnused,
+				ReferencedBy: []string{"#/definitions/orphan/properties/thing"},
+			},
+		}, report.Definitions)
+		assert.Equal(t, []UnusedComponent{
+			{Pointer: "#/parameters/unusedParam", Name: "unusedParam", Reason: UnusedNeverReferenced},
+		}, report.Parameters)
+		assert.Equal(t, []UnusedComponent{
+			{Pointer: "#/responses/unusedResponse", Name: "unusedResponse", Reason: UnusedNeverReferenced},
+		}, report.Responses)
+		assert.Equal(t, []UnusedComponent{
+			{Pointer: "#/securityDefinitions/basic", Name: "basic", Reason: UnusedNeverReferenced},
+		}, report.SecurityDefinitions)
+		assert.Equal(t, []UnusedComponent{
+			{Pointer: "#/tags/1", Name: "store", Reason: UnusedNeverReferenced},
+		}, report.Tags)
+
+		// the spec is left unchanged
+		assert.JSONEq(t, snapshot, antest.AsJSON(t, doc))
+	})
+
+	t.Run("should report nothing", func(t *testing.T) {
+		doc := antest.LoadOrFail(t, filepath.Join("fixtures", "usage.yml"))
+		delete(doc.Definitions, "unused")
+
+		assert.True(t, New(doc).UnusedReport().IsEmpty())
+	})
+}
@@ -0,0 +1,171 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"strings"
+	"sync"
+)
+
+// SchemaUsage describes how a schema flows through the operations of a spec.
+//
+// A schema may be used in requests (i.e. body parameters), in responses or both.
+// It is flagged with [UsageReadOnly] whenever it is reached through a readOnly schema.
+type SchemaUsage uint8
+
+const (
+	// UsageRequest flags schemas used by some operation parameter.
+	UsageRequest SchemaUsage = 1 << iota
+	// UsageResponse flags schemas used by some operation response.
+	UsageResponse
+	// UsageReadOnly flags schemas used under some readOnly property.
+	UsageReadOnly
+)
+
+// IsUnused is true when the schema is not reachable from any operation.
+func (u SchemaUsage) IsUnused() bool {
+	return u&(UsageRequest|UsageResponse) == 0
+}
+
+// IsRequestOnly is true when the schema is used by requests, but not by responses.
+func (u SchemaUsage) IsRequestOnly() bool {
+	return u&UsageRequest != 0 && u&UsageResponse == 0
+}
+
+// IsResponseOnly is true when the schema is used by responses, but not by requests.
+func (u SchemaUsage) IsResponseOnly() bool {
+	return u&UsageResponse != 0 && u&UsageRequest == 0
+}
+
+// IsBoth is true when the schema is used by both requests and responses.
+func (u SchemaUsage) IsBoth() bool {
+	return u&UsageRequest != 0 && u&UsageResponse != 0
+}
+
+// IsReadOnly is true when the schema is used under some readOnly property.
+func (u SchemaUsage) IsReadOnly() bool {
+	return u&UsageReadOnly != 0
+}
+
+// String representation of the usage, e.g. "request|response|readOnly" or "unused".
+func (u SchemaUsage) String() string {
+	if u == 0 {
+		return "unused"
+	}
+
+	flags := make([]string, 0, 3) //nolint:mnd // there are 3 flags
+	if u&UsageRequest != 0 {
+		flags = append(flags, "request")
+	}
+	if u&UsageResponse != 0 {
+		flags = append(flags, "response")
+	}
+	if u&UsageReadOnly != 0 {
+		flags = append(flags, "readOnly")
+	}
+
+	return strings.Join(flags, "|")
+}
+
+// usageAnalysis collects the locations of operation parameters and responses, from which
+// the usage of schemas is derived.
+type usageAnalysis struct {
+	roots   map[string]SchemaUsage
+	schemas map[string]SchemaUsage
+	mu      sync.Mutex // protects schemas, which are computed lazily by readers
+}
+
+func (u *usageAnalysis) addRoot(key string, usage SchemaUsage) {
+	u.roots["#"+key] |= usage
+	u.schemas = nil
+}
+
+// SchemaUsageFor returns the usage of the schema located at the JSON pointer,
+// e.g. "#/definitions/Pet" or "#/paths/~1pets/get/responses/200/schema".
+//
+// Usage is propagated transitively through $ref, allOf, items and any other nested schema.
+// Schemas which are not reachable from any operation are reported as unused (i.e. 0).
+func (s *Spec) SchemaUsageFor(pointer string) SchemaUsage {
+	return s.schemaUsages()[normalizePointer(pointer)]
+}
+
+// DefinitionUsages returns the usage of every definition, indexed by definition name.
+func (s *Spec) DefinitionUsages() map[string]SchemaUsage {
+	usages := s.schemaUsages()
+	result := make(map[string]SchemaUsage, len(s.spec.Definitions))
+	for _, schRef := range s.allSchemas {
+		if !schRef.TopLevel {
+			continue
+		}
+
+		result[schRef.Name] = usages[schRef.Ref.String()]
+	}
+
+	return result
+}
+
+// schemaUsages propagates usage from operations to schemas.
+//
+// The result is computed upon first use and kept until some part of the spec is analyzed again.
+func (s *Spec) schemaUsages() map[string]SchemaUsage {
+	s.usages.mu.Lock()
+	defer s.usages.mu.Unlock()
+
+	if s.usages.schemas != nil {
+		return s.usages.schemas
+	}
+
+	type reached struct {
+		key   string
+		usage SchemaUsage
+	}
+
+	usages := make(map[string]SchemaUsage, len(s.allSchemas))
+	visited := make(map[string]SchemaUsage, allocMediumMap)
+	pending := make([]reached, 0, len(s.usages.roots))
+	for key, usage := range s.usages.roots {
+		pending = append(pending, reached{key: key, usage: usage})
+	}
+
+	for len(pending) > 0 {
+		current := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		if visited[current.key]&current.usage == current.usage {
+			continue
+		}
+		visited[current.key] |= current.usage
+
+		for key := range s.allSchemas {
+			if isUnder(key, current.key) {
+				usages[key] |= current.usage | s.readOnlyBetween(current.key, key)
+			}
+		}
+
+		for key, ref := range s.references.allRefs {
+			target := ref.String()
+			if !isUnder(key, current.key) || !strings.HasPrefix(target, "#/") {
+				continue
+			}
+
+			pending = append(pending, reached{key: target, usage: current.usage | s.readOnlyBetween(current.key, key)})
+		}
+	}
+
+	s.usages.schemas = usages
+
+	return usages
+}
+
+// readOnlyBetween yields [UsageReadOnly] whenever some schema located between root and key
+// (both included) is readOnly.
+func (s *Spec) readOnlyBetween(root, key string) SchemaUsage {
+	for ; len(key) >= len(root); key = key[:strings.LastIndexByte(key, '/')] {
+		if schRef, ok := s.allSchemas[key]; ok && schRef.Schema != nil && schRef.Schema.ReadOnly {
+			return UsageReadOnly
+		}
+	}
+
+	return 0
+}
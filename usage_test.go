@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/analysis/internal/antest"
+	"github.com/go-openapi/spec"
+	"github.com/go-openapi/testify/v2/assert"
+)
+
+func TestAnalyzer_SchemaUsage(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should classify definitions", func(t *testing.T) {
+		doc := antest.LoadOrFail(t, filepath.Join("fixtures", "usage.yml"))
+		an := New(doc)
+
+		assert.Equal(t, map[string]SchemaUsage{
+			"base":   UsageRequest | UsageResponse,
+			"newPet": UsageRequest,
+			"tag":    UsageRequest,
+			"pet":    UsageRequest | UsageResponse,
+			"audit":  UsageRequest | UsageResponse | UsageReadOnly,
+			"error":  UsageResponse,
+			"unused": 0,
+		}, an.DefinitionUsages())
+
+		assert.True(t, an.SchemaUsageFor("#/definitions/newPet").IsRequestOnly())
+		assert.True(t, an.SchemaUsageFor("/definitions/error").IsResponseOnly())
+		assert.True(t, an.SchemaUsageFor("#/definitions/base").IsBoth())
+		assert.True(t, an.SchemaUsageFor("#/definitions/unused").IsUnused())
+		assert.True(t, an.SchemaUsageFor("#/definitions/pet/allOf/1/properties/id").IsReadOnly())
+		assert.False(t, an.SchemaUsageFor("#/definitions/pet/allOf/1").IsReadOnly())
+		assert.True(t, an.SchemaUsageFor("#/definitions/audit/properties/createdAt").IsReadOnly())
+		assert.Equal(t, UsageResponse, an.SchemaUsageFor("#/paths/~1pets/get/responses/200/schema/items"))
+		assert.Equal(t, UsageRequest, an.SchemaUsageFor("#/paths/~1pets~1{id}/put/parameters/0/schema"))
+	})
+
+	t.Run("should update usage after reanalysis", func(t *testing.T) {
+		doc := antest.LoadOrFail(t, filepath.Join("fixtures", "usage.yml"))
+		an := New(doc)
+		assert.True(t, an.SchemaUsageFor("#/definitions/unused").IsUnused())
+
+		op := doc.Paths.Paths["/pets"].Get
+		op.Parameters = append(op.Parameters, *spec.BodyParam("filter", spec.RefSchema("#/definitions/unused")))
+		an.Reanalyze("#/paths/~1pets/get")
+
+		assert.True(t, an.SchemaUsageFor("#/definitions/unused").IsRequestOnly())
+		assert.Equal(t, New(doc).DefinitionUsages(), an.DefinitionUsages())
+	})
+}
+
+func TestSchemaUsage_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "unused", SchemaUsage(0).String())
+	assert.Equal(t, "request", UsageRequest.String())
+	assert.Equal(t, "request|response|readOnly", (UsageRequest | UsageResponse | UsageReadOnly).String())
+}